@@ -0,0 +1,186 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/types"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Truenya/usestruct/pkg/analyzer"
+	"golang.org/x/tools/go/packages"
+)
+
+// itemsetsConfig holds the flags of the itemsets subcommand
+type itemsetsConfig struct {
+	minSupport int
+	minSize    int
+	maxSize    int
+	by         string
+	all        bool
+	json       bool
+	tests      bool
+}
+
+// runItemsets mines function signatures of the given packages for parameter groups
+// that co-occur in many functions and prints them ranked by support and size
+func runItemsets(args []string) int {
+	var cfg itemsetsConfig
+	fs := flag.NewFlagSet("itemsets", flag.ContinueOnError)
+	fs.IntVar(&cfg.minSupport, "min-support", 3, "minimum number of functions sharing a parameter group")
+	fs.IntVar(&cfg.minSize, "min-size", 2, "minimum number of parameters in a reported group")
+	fs.IntVar(&cfg.maxSize, "max-size", 6, "maximum number of parameters in a group (0 means unlimited)")
+	fs.StringVar(&cfg.by, "by", "param", "what identifies a parameter: param (name and type), type or name")
+	fs.BoolVar(&cfg.all, "all", false, "report all frequent groups, not only closed ones")
+	fs.BoolVar(&cfg.json, "json", false, "print the report as JSON")
+	fs.BoolVar(&cfg.tests, "tests", false, "include test files")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: usestruct itemsets [flags] packages...")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	itemFunc, ok := itemFuncs[cfg.by]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "usestruct: unknown -by value %q\n", cfg.by)
+		return 2
+	}
+
+	patterns := fs.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	pkgs, err := packages.Load(&packages.Config{
+		Mode:  packages.NeedName | packages.NeedImports | packages.NeedDeps | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo,
+		Tests: cfg.tests,
+	}, patterns...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "usestruct: %v\n", err)
+		return 1
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return 1
+	}
+
+	sets := analyzer.MineItemsets(collectSignatures(pkgs, itemFunc), analyzer.ItemsetOptions{
+		MinSupport: cfg.minSupport,
+		MinSize:    cfg.minSize,
+		MaxSize:    cfg.maxSize,
+		Closed:     !cfg.all,
+	})
+
+	if cfg.json {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(sets); err != nil {
+			fmt.Fprintf(os.Stderr, "usestruct: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	printItemsets(os.Stdout, sets)
+	return 0
+}
+
+// itemFunc returns the items of a single signature
+type itemFunc func(params *types.Tuple, qf types.Qualifier) []string
+
+var itemFuncs = map[string]itemFunc{
+	"param": paramItems,
+	"type":  typeItems,
+	"name":  nameItems,
+}
+
+// paramItems identifies parameters by name and type
+func paramItems(params *types.Tuple, qf types.Qualifier) []string {
+	var items []string
+	for i := range params.Len() {
+		v := params.At(i)
+		if v.Name() == "" || v.Name() == "_" {
+			continue
+		}
+		items = append(items, v.Name()+" "+types.TypeString(v.Type(), qf))
+	}
+	return items
+}
+
+// typeItems identifies parameters by type; repeated types get a "#n" suffix
+// so that (x, y int) and (x int) are different groups
+func typeItems(params *types.Tuple, qf types.Qualifier) []string {
+	var items []string
+	seen := make(map[string]int)
+	for i := range params.Len() {
+		v := params.At(i)
+		t := types.TypeString(v.Type(), qf)
+		seen[t]++
+		if n := seen[t]; n > 1 {
+			t = fmt.Sprintf("%s#%d", t, n)
+		}
+		items = append(items, t)
+	}
+	return items
+}
+
+// nameItems identifies parameters by name only
+func nameItems(params *types.Tuple, _ types.Qualifier) []string {
+	var items []string
+	for i := range params.Len() {
+		v := params.At(i)
+		if v.Name() == "" || v.Name() == "_" {
+			continue
+		}
+		items = append(items, v.Name())
+	}
+	return items
+}
+
+// collectSignatures returns signatures of all function declarations in the packages
+func collectSignatures(pkgs []*packages.Package, items itemFunc) []analyzer.Signature {
+	qf := func(p *types.Package) string { return p.Name() }
+
+	var sigs []analyzer.Signature
+	seen := make(map[string]struct{})
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Syntax {
+			for _, decl := range file.Decls {
+				funcDecl, ok := decl.(*ast.FuncDecl)
+				if !ok {
+					continue
+				}
+				fn, ok := pkg.TypesInfo.Defs[funcDecl.Name].(*types.Func)
+				if !ok {
+					continue
+				}
+				// Test variants of a package repeat its declarations
+				if _, ok := seen[fn.FullName()]; ok {
+					continue
+				}
+				seen[fn.FullName()] = struct{}{}
+
+				sig := fn.Type().(*types.Signature)
+				sigs = append(sigs, analyzer.Signature{
+					Name:  fn.FullName(),
+					Items: items(sig.Params(), qf),
+				})
+			}
+		}
+	}
+
+	return sigs
+}
+
+func printItemsets(w io.Writer, sets []analyzer.Itemset) {
+	for _, s := range sets {
+		fmt.Fprintf(w, "support=%d size=%d: %s\n", s.Support, len(s.Items), strings.Join(s.Items, ", "))
+		for _, f := range s.Funcs {
+			fmt.Fprintf(w, "\t%s\n", f)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Command usestruct runs the usestruct analyzers as a standalone tool.
+//
+// Usage:
+//
+//	usestruct [flags] packages...           run the analyzers
+//	usestruct itemsets [flags] packages...  report parameter groups shared by many functions
+//	usestruct refactor [flags] packages...  print or apply (-w) the fixes of selected diagnostics
+//
+// Each package is configured by the .usestruct.yml or .usestruct.json files of its directory
+// and its parents, which use the keys of the golangci-lint settings.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Truenya/usestruct"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/multichecker"
+)
+
+func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "itemsets":
+			os.Exit(runItemsets(os.Args[2:]))
+		case "refactor":
+			os.Exit(runRefactor(os.Args[2:], os.Stdout, os.Stderr))
+		}
+	}
+
+	analyzers, err := loadAnalyzers()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "usestruct: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Fixes are type-checked in memory before they are offered and must keep the exported API.
+	// Importers are not type-checked again, only the API of the package is compared
+	verifier := newFixVerifier(os.Stderr)
+	for i, a := range analyzers {
+		analyzers[i] = verifier.wrap(a)
+	}
+
+	multichecker.Main(analyzers...)
+}
+
+// loadAnalyzers builds the analyzers configured by the .usestruct.yml or .usestruct.json files
+// of the directory of each package and its parents
+func loadAnalyzers() ([]*analysis.Analyzer, error) {
+	plugin, err := usestruct.NewWithConfigFiles(".")
+	if err != nil {
+		return nil, err
+	}
+	return plugin.BuildAnalyzers()
+}
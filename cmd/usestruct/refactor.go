@@ -0,0 +1,375 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+// refactorConfig holds the flags of the refactor subcommand
+type refactorConfig struct {
+	fingerprints string
+	funcs        string
+	files        string
+	fix          string
+	write        bool
+	tests        bool
+}
+
+// selectedFix is a suggested fix chosen for a diagnostic
+type selectedFix struct {
+	fingerprint string
+	fset        *token.FileSet
+	diag        analysis.Diagnostic
+	fix         analysis.SuggestedFix
+}
+
+// fileEdits collects the edits of all selected fixes in one file
+type fileEdits struct {
+	file  *token.File
+	edits []analysis.TextEdit
+}
+
+// runRefactor prints a unified diff of the suggested fixes for the selected diagnostics
+// or applies them with -w
+func runRefactor(args []string, stdout, stderr io.Writer) int {
+	var cfg refactorConfig
+	fs := flag.NewFlagSet("refactor", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&cfg.fingerprints, "fingerprint", "", "comma-separated fingerprints of diagnostics to fix")
+	fs.StringVar(&cfg.funcs, "func", "", "comma-separated function names (Func or Type.Method) the diagnostic is reported at or whose call stack it covers")
+	fs.StringVar(&cfg.files, "file", "", "comma-separated file names or path suffixes the diagnostic is reported in")
+	fs.StringVar(&cfg.fix, "fix", "", "substring of the fix message to choose when a diagnostic offers several fixes (default: the first fix)")
+	fs.BoolVar(&cfg.write, "w", false, "write the changes to the files instead of printing a diff")
+	fs.BoolVar(&cfg.tests, "tests", false, "include test files")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: usestruct refactor [flags] packages...")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	patterns := fs.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	pkgs, err := packages.Load(&packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles | packages.NeedImports | packages.NeedDeps |
+			packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedTypesSizes,
+		Tests: cfg.tests,
+	}, patterns...)
+	if err != nil {
+		fmt.Fprintf(stderr, "usestruct: %v\n", err)
+		return 1
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return 1
+	}
+
+	analyzers, err := loadAnalyzers()
+	if err != nil {
+		fmt.Fprintf(stderr, "usestruct: %v\n", err)
+		return 1
+	}
+	verifier := newFixVerifier(stderr)
+	for i, a := range analyzers {
+		analyzers[i] = verifier.wrap(a)
+	}
+
+	graph, err := checker.Analyze(analyzers, pkgs, nil)
+	if err != nil {
+		fmt.Fprintf(stderr, "usestruct: %v\n", err)
+		return 1
+	}
+
+	fixes := selectFixes(graph, cfg)
+	if len(fixes) == 0 {
+		fmt.Fprintln(stderr, "usestruct: no fixes selected")
+		return 0
+	}
+
+	files := mergeFixes(fixes, stderr)
+	return writeChanges(files, cfg.write, stdout, stderr)
+}
+
+// selectFixes returns the chosen fixes of the diagnostics matching the selection flags,
+// ordered by position. Duplicates reported for test variants of a package are dropped
+func selectFixes(graph *checker.Graph, cfg refactorConfig) []selectedFix {
+	var fixes []selectedFix
+	seen := make(map[string]bool)
+	for _, act := range graph.Roots {
+		if act.Err != nil {
+			continue
+		}
+		fset := act.Package.Fset
+		for _, d := range act.Diagnostics {
+			fp := fingerprint(act.Analyzer, act.Package.PkgPath, d)
+			key := fp + "@" + fset.Position(d.Pos).String()
+			if seen[key] || !matchDiagnostic(act.Package, d, fp, cfg) {
+				continue
+			}
+			fix, ok := chooseFix(d, cfg.fix)
+			if !ok {
+				continue
+			}
+			seen[key] = true
+			fixes = append(fixes, selectedFix{fingerprint: fp, fset: fset, diag: d, fix: fix})
+		}
+	}
+
+	sort.SliceStable(fixes, func(i, j int) bool {
+		pi, pj := fixes[i].fset.Position(fixes[i].diag.Pos), fixes[j].fset.Position(fixes[j].diag.Pos)
+		if pi.Filename != pj.Filename {
+			return pi.Filename < pj.Filename
+		}
+		return pi.Offset < pj.Offset
+	})
+	return fixes
+}
+
+// fingerprint identifies a diagnostic independently of its position,
+// so that it survives unrelated edits of the file
+func fingerprint(a *analysis.Analyzer, pkgPath string, d analysis.Diagnostic) string {
+	sum := sha256.Sum256([]byte(a.Name + "\x00" + pkgPath + "\x00" + d.Message))
+	return hex.EncodeToString(sum[:6])
+}
+
+// matchDiagnostic reports whether the diagnostic is selected by the flags.
+// Without selection flags every diagnostic is selected
+func matchDiagnostic(pkg *packages.Package, d analysis.Diagnostic, fp string, cfg refactorConfig) bool {
+	if cfg.fingerprints != "" && !slices.Contains(splitList(cfg.fingerprints), fp) {
+		return false
+	}
+	if cfg.files != "" {
+		filename := filepath.ToSlash(pkg.Fset.Position(d.Pos).Filename)
+		matched := false
+		for _, f := range splitList(cfg.files) {
+			f = filepath.ToSlash(f)
+			if filename == f || strings.HasSuffix(filename, "/"+f) {
+				matched = true
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if cfg.funcs != "" {
+		names := diagnosticFuncs(pkg, d)
+		matched := false
+		for _, f := range splitList(cfg.funcs) {
+			if slices.Contains(names, f) {
+				matched = true
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// diagnosticFuncs returns the function the diagnostic is reported at
+// and the functions of the call stack mentioned in its message
+func diagnosticFuncs(pkg *packages.Package, d analysis.Diagnostic) []string {
+	var names []string
+	for _, file := range pkg.Syntax {
+		if file.Pos() > d.Pos || d.Pos > file.End() {
+			continue
+		}
+		for _, decl := range file.Decls {
+			f, ok := decl.(*ast.FuncDecl)
+			if !ok || f.Pos() > d.Pos || d.Pos > f.End() {
+				continue
+			}
+			names = append(names, funcName(f))
+		}
+	}
+
+	if _, stack, ok := strings.Cut(d.Message, "call stack: "); ok {
+		stack, _, _ = strings.Cut(stack, ", ")
+		names = append(names, strings.Split(stack, " -> ")...)
+	}
+	return names
+}
+
+// funcName returns the name of a function as Func or Type.Method
+func funcName(f *ast.FuncDecl) string {
+	if f.Recv == nil || len(f.Recv.List) == 0 {
+		return f.Name.Name
+	}
+	t := f.Recv.List[0].Type
+	for {
+		switch x := t.(type) {
+		case *ast.StarExpr:
+			t = x.X
+			continue
+		case *ast.IndexExpr:
+			t = x.X
+			continue
+		case *ast.IndexListExpr:
+			t = x.X
+			continue
+		case *ast.Ident:
+			return x.Name + "." + f.Name.Name
+		}
+		return f.Name.Name
+	}
+}
+
+// chooseFix returns the first fix whose message contains the substring
+func chooseFix(d analysis.Diagnostic, substr string) (analysis.SuggestedFix, bool) {
+	for _, fix := range d.SuggestedFixes {
+		if strings.Contains(fix.Message, substr) {
+			return fix, true
+		}
+	}
+	return analysis.SuggestedFix{}, false
+}
+
+// mergeFixes groups the edits of the fixes by file. A fix whose edits overlap
+// with an earlier fix is skipped entirely and reported
+func mergeFixes(fixes []selectedFix, stderr io.Writer) map[string]*fileEdits {
+	files := make(map[string]*fileEdits)
+	for _, sf := range fixes {
+		pending := make(map[string][]analysis.TextEdit)
+		conflict := false
+		for _, edit := range sf.fix.TextEdits {
+			file := sf.fset.File(edit.Pos)
+			if file == nil {
+				conflict = true
+				break
+			}
+			if fe, ok := files[file.Name()]; ok && overlaps(fe.edits, edit) {
+				conflict = true
+				break
+			}
+			pending[file.Name()] = append(pending[file.Name()], edit)
+		}
+		if conflict {
+			fmt.Fprintf(stderr, "usestruct: skipped fix %q at %s: conflicts with another selected fix\n",
+				sf.fix.Message, sf.fset.Position(sf.diag.Pos))
+			continue
+		}
+
+		fmt.Fprintf(stderr, "%s %s: %s\n\t%s\n", sf.fingerprint, sf.fset.Position(sf.diag.Pos), sf.diag.Message, sf.fix.Message)
+		for name, edits := range pending {
+			fe, ok := files[name]
+			if !ok {
+				fe = &fileEdits{file: sf.fset.File(edits[0].Pos)}
+				files[name] = fe
+			}
+			for _, edit := range edits {
+				if !containsEdit(fe.edits, edit) {
+					fe.edits = append(fe.edits, edit)
+				}
+			}
+		}
+	}
+	return files
+}
+
+// overlaps reports whether the edit intersects one of the edits, ignoring identical ones
+func overlaps(edits []analysis.TextEdit, edit analysis.TextEdit) bool {
+	for _, e := range edits {
+		if sameEdit(e, edit) {
+			continue
+		}
+		if edit.Pos < e.End && e.Pos < edit.End || edit.Pos == e.Pos {
+			return true
+		}
+	}
+	return false
+}
+
+// containsEdit reports whether an identical edit is already collected
+func containsEdit(edits []analysis.TextEdit, edit analysis.TextEdit) bool {
+	for _, e := range edits {
+		if sameEdit(e, edit) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameEdit reports whether two edits are identical
+func sameEdit(a, b analysis.TextEdit) bool {
+	return a.Pos == b.Pos && a.End == b.End && string(a.NewText) == string(b.NewText)
+}
+
+// writeChanges applies the edits and either writes the files or prints their diff
+func writeChanges(files map[string]*fileEdits, write bool, stdout, stderr io.Writer) int {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	status := 0
+	for _, name := range names {
+		fe := files[name]
+		src, err := os.ReadFile(name)
+		if err != nil {
+			fmt.Fprintf(stderr, "usestruct: %v\n", err)
+			status = 1
+			continue
+		}
+		out, err := applyEdits(fe.file, src, fe.edits)
+		if err != nil {
+			fmt.Fprintf(stderr, "usestruct: %s: %v\n", name, err)
+			status = 1
+			continue
+		}
+		if formatted, err := format.Source(out); err == nil {
+			out = formatted
+		}
+
+		if write {
+			info, err := os.Stat(name)
+			if err == nil {
+				err = os.WriteFile(name, out, info.Mode().Perm())
+			}
+			if err != nil {
+				fmt.Fprintf(stderr, "usestruct: %v\n", err)
+				status = 1
+			}
+			continue
+		}
+
+		rel := name
+		if wd, err := os.Getwd(); err == nil {
+			if r, err := filepath.Rel(wd, name); err == nil && !strings.HasPrefix(r, "..") {
+				rel = r
+			}
+		}
+		rel = filepath.ToSlash(rel)
+		fmt.Fprint(stdout, unifiedDiff("a/"+rel, "b/"+rel, src, out))
+	}
+	return status
+}
+
+// splitList splits a comma-separated flag value
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	a := "package p\n\nfunc a() {}\n\nfunc b() {}\n\nfunc c() {}\n"
+	b := "package p\n\nfunc a() {}\n\nfunc b(x int) {}\n\nfunc c() {}\n"
+
+	expected := `--- a/p.go
++++ b/p.go
+@@ -2,6 +2,6 @@
+ 
+ func a() {}
+ 
+-func b() {}
++func b(x int) {}
+ 
+ func c() {}
+`
+	if got := unifiedDiff("a/p.go", "b/p.go", []byte(a), []byte(b)); got != expected {
+		t.Errorf("unifiedDiff() =\n%s\nwant\n%s", got, expected)
+	}
+	if got := unifiedDiff("a/p.go", "b/p.go", []byte(a), []byte(a)); got != "" {
+		t.Errorf("expected empty diff for equal files, got\n%s", got)
+	}
+}
+
+func TestUnifiedDiffSeparateHunks(t *testing.T) {
+	var a, b strings.Builder
+	for i := range 20 {
+		line := fmt.Sprintf("line %d\n", i)
+		a.WriteString(line)
+		if i == 1 || i == 18 {
+			line = "changed\n"
+		}
+		b.WriteString(line)
+	}
+
+	diff := unifiedDiff("a", "b", []byte(a.String()), []byte(b.String()))
+	if n := strings.Count(diff, "@@ -"); n != 2 {
+		t.Errorf("expected 2 hunks, got %d:\n%s", n, diff)
+	}
+	if !strings.Contains(diff, "@@ -1,5 +1,5 @@") || !strings.Contains(diff, "@@ -16,5 +16,5 @@") {
+		t.Errorf("unexpected hunk ranges:\n%s", diff)
+	}
+}
+
+func TestRefactorDryRun(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := runRefactor([]string{"-func", "connect", "-fix", "method object", "../../pkg/analyzer/testdata/src/methodobject"}, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("runRefactor() = %d, stderr:\n%s", code, stderr.String())
+	}
+
+	diff := stdout.String()
+	for _, line := range []string{
+		"+++ b/",
+		"+type exportParams struct {",
+		"+func (o exportParams) connect() error {",
+		"-func connect(host string, port, retries int) error {",
+	} {
+		if !strings.Contains(diff, line) {
+			t.Errorf("diff does not contain %q:\n%s", line, diff)
+		}
+	}
+	if !strings.Contains(stderr.String(), "Introduce method object exportParams") {
+		t.Errorf("selected fixes are not listed:\n%s", stderr.String())
+	}
+}
+
+func TestRefactorNoMatch(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := runRefactor([]string{"-fingerprint", "000000000000", "../../pkg/analyzer/testdata/src/methodobject"}, &stdout, &stderr)
+	if code != 0 || stdout.Len() != 0 || !strings.Contains(stderr.String(), "no fixes selected") {
+		t.Errorf("runRefactor() = %d, stdout:\n%s\nstderr:\n%s", code, stdout.String(), stderr.String())
+	}
+}
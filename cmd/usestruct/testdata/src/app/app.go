@@ -0,0 +1,7 @@
+package app
+
+import "lib"
+
+func page() {
+	lib.Render("title", "header", "body")
+}
@@ -0,0 +1,10 @@
+package lib
+
+// Render renders a page.
+func Render(title, header, body string) {
+	draw(title, header, body)
+}
+
+func draw(t, h, b string) { // want "make struct with arguments: string, string, string, for call stack: Render -> draw"
+	_ = t + h + b
+}
@@ -0,0 +1,5 @@
+package verify
+
+func target() {} // want "function target"
+
+func caller() { target() }
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// maxVerifyErrors limits the number of compiler errors reported for a withheld fix
+const maxVerifyErrors = 3
+
+// fixVerifier applies suggested fixes in memory, type-checks the result and compares
+// the exported API, so that fixes which would break the build are never offered
+type fixVerifier struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+// newFixVerifier returns a verifier that reports withheld fixes to out
+func newFixVerifier(out io.Writer) *fixVerifier {
+	return &fixVerifier{out: out}
+}
+
+// wrap returns a copy of the analyzer whose diagnostics only carry fixes that compile.
+// Diagnostics whose fixes were all withheld are still reported, without fixes
+func (v *fixVerifier) wrap(a *analysis.Analyzer) *analysis.Analyzer {
+	wrapped := *a
+	wrapped.Run = func(pass *analysis.Pass) (any, error) {
+		verified := *pass
+		verified.Report = func(d analysis.Diagnostic) {
+			d.SuggestedFixes = v.verify(pass, d.SuggestedFixes)
+			pass.Report(d)
+		}
+		return a.Run(&verified)
+	}
+	return &wrapped
+}
+
+// verify returns the fixes that keep the package compiling and reports the rest
+func (v *fixVerifier) verify(pass *analysis.Pass, fixes []analysis.SuggestedFix) []analysis.SuggestedFix {
+	var kept []analysis.SuggestedFix
+	for _, fix := range fixes {
+		if err := checkFix(pass, fix); err != nil {
+			v.report(pass, fix, err)
+			continue
+		}
+		kept = append(kept, fix)
+	}
+	return kept
+}
+
+// report prints a withheld fix together with the errors it caused
+func (v *fixVerifier) report(pass *analysis.Pass, fix analysis.SuggestedFix, err error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	pos := token.NoPos
+	if len(fix.TextEdits) > 0 {
+		pos = fix.TextEdits[0].Pos
+	}
+	fmt.Fprintf(v.out, "usestruct: withheld fix %q at %s:\n", fix.Message, pass.Fset.Position(pos))
+	for _, line := range strings.Split(err.Error(), "\n") {
+		fmt.Fprintf(v.out, "\t%s\n", line)
+	}
+}
+
+// checkFix applies the fix to the package sources in memory and type-checks the result.
+// Importing packages are not checked again, so fixes of importable packages must also keep
+// their exported API
+func checkFix(pass *analysis.Pass, fix analysis.SuggestedFix) error {
+	pkg, err := checkPackage(pass, fix)
+	if err != nil {
+		return err
+	}
+	if pass.Pkg.Name() == "main" {
+		return nil
+	}
+	return compareAPI(pass.Pkg, pkg)
+}
+
+// checkPackage applies the fix to the package sources in memory and returns the type-checked result
+func checkPackage(pass *analysis.Pass, fix analysis.SuggestedFix) (*types.Package, error) {
+	sources, err := applyFix(pass, fix)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	files := make([]*ast.File, 0, len(pass.Files))
+	for _, f := range pass.Files {
+		name := pass.Fset.File(f.Pos()).Name()
+		src, ok := sources[name]
+		if !ok {
+			if src, err = pass.ReadFile(name); err != nil {
+				return nil, err
+			}
+		}
+		file, err := parser.ParseFile(fset, name, src, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+
+	var errs []error
+	conf := types.Config{
+		Importer:  packageImporter(pass.Pkg),
+		Sizes:     pass.TypesSizes,
+		GoVersion: pass.Pkg.GoVersion(),
+		Error: func(err error) {
+			if len(errs) < maxVerifyErrors {
+				errs = append(errs, err)
+			}
+		},
+	}
+	pkg, _ := conf.Check(pass.Pkg.Path(), fset, files, nil)
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return pkg, nil
+}
+
+// compareAPI reports exported declarations of the original package that the fixed package
+// removes or changes. New declarations are allowed
+func compareAPI(orig, fixed *types.Package) error {
+	var errs []error
+	for _, name := range orig.Scope().Names() {
+		obj := orig.Scope().Lookup(name)
+		if !obj.Exported() {
+			continue
+		}
+		was := apiOf(obj)
+		switch now := fixed.Scope().Lookup(name); {
+		case now == nil:
+			errs = append(errs, fmt.Errorf("removes exported %s", was))
+		case apiOf(now) != was:
+			errs = append(errs, fmt.Errorf("changes exported %s to %s", was, apiOf(now)))
+		}
+		if len(errs) == maxVerifyErrors {
+			break
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// apiOf describes an exported declaration as seen by importing packages:
+// its type and, for a named type, its exported methods
+func apiOf(obj types.Object) string {
+	qualifier := func(p *types.Package) string { return p.Path() }
+	api := types.ObjectString(obj, qualifier)
+	tn, ok := obj.(*types.TypeName)
+	if !ok || tn.IsAlias() {
+		return api
+	}
+	if named, ok := tn.Type().(*types.Named); ok {
+		for i := range named.NumMethods() {
+			if m := named.Method(i); m.Exported() {
+				api += "; " + types.ObjectString(m, qualifier)
+			}
+		}
+	}
+	return api
+}
+
+// applyFix returns the contents of the files changed by the fix
+func applyFix(pass *analysis.Pass, fix analysis.SuggestedFix) (map[string][]byte, error) {
+	byFile := make(map[string][]analysis.TextEdit)
+	for _, edit := range fix.TextEdits {
+		file := pass.Fset.File(edit.Pos)
+		if file == nil {
+			return nil, fmt.Errorf("edit at unknown position %d", edit.Pos)
+		}
+		byFile[file.Name()] = append(byFile[file.Name()], edit)
+	}
+
+	sources := make(map[string][]byte, len(byFile))
+	for name, edits := range byFile {
+		src, err := pass.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		if sources[name], err = applyEdits(pass.Fset.File(edits[0].Pos), src, edits); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return sources, nil
+}
+
+// applyEdits applies non-overlapping edits to the contents of a file
+func applyEdits(file *token.File, src []byte, edits []analysis.TextEdit) ([]byte, error) {
+	edits = append([]analysis.TextEdit(nil), edits...)
+	// Insertions go before replacements starting at the same position
+	sort.SliceStable(edits, func(i, j int) bool {
+		if edits[i].Pos != edits[j].Pos {
+			return edits[i].Pos < edits[j].Pos
+		}
+		return edits[i].End < edits[j].End
+	})
+
+	var out []byte
+	last := 0
+	for _, edit := range edits {
+		end := edit.End
+		if !end.IsValid() {
+			end = edit.Pos
+		}
+		start, stop := file.Offset(edit.Pos), file.Offset(end)
+		if start < last || stop < start || stop > len(src) {
+			return nil, fmt.Errorf("overlapping or invalid edit at offset %d", start)
+		}
+		out = append(out, src[last:start]...)
+		out = append(out, edit.NewText...)
+		last = stop
+	}
+	return append(out, src[last:]...), nil
+}
+
+// packageImporter resolves imports to the packages the original package was checked against.
+// Imports added by a fix fall back to the default importer
+func packageImporter(pkg *types.Package) types.Importer {
+	imports := make(map[string]*types.Package)
+	for _, imp := range pkg.Imports() {
+		imports[imp.Path()] = imp
+	}
+	return importerFunc(func(path string) (*types.Package, error) {
+		if path == "unsafe" {
+			return types.Unsafe, nil
+		}
+		if imp, ok := imports[path]; ok {
+			return imp, nil
+		}
+		return importer.Default().Import(path)
+	})
+}
+
+// importerFunc adapts a function to the types.Importer interface
+type importerFunc func(path string) (*types.Package, error)
+
+// Import implements types.Importer
+func (f importerFunc) Import(path string) (*types.Package, error) { return f(path) }
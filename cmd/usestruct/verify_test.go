@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Truenya/usestruct/pkg/analyzer"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// fixesAnalyzer offers a fix that breaks callers and a fix that compiles
+var fixesAnalyzer = &analysis.Analyzer{
+	Name: "fixes",
+	Doc:  "test analyzer offering compiling and broken fixes",
+	Run: func(pass *analysis.Pass) (any, error) {
+		for _, file := range pass.Files {
+			for _, decl := range file.Decls {
+				f, ok := decl.(*ast.FuncDecl)
+				if !ok || f.Name.Name != "target" {
+					continue
+				}
+				pass.Report(analysis.Diagnostic{
+					Pos:     f.Pos(),
+					Message: "function target",
+					SuggestedFixes: []analysis.SuggestedFix{
+						{
+							Message:   "Rename to renamed",
+							TextEdits: []analysis.TextEdit{{Pos: f.Name.Pos(), End: f.Name.End(), NewText: []byte("renamed")}},
+						},
+						{
+							Message:   "Add comment",
+							TextEdits: []analysis.TextEdit{{Pos: f.Pos(), End: f.Pos(), NewText: []byte("// target is a target.\n")}},
+						},
+					},
+				})
+			}
+		}
+		return nil, nil
+	},
+}
+
+func TestFixVerifier(t *testing.T) {
+	var out bytes.Buffer
+	verifier := newFixVerifier(&out)
+
+	results := analysistest.Run(t, analysistest.TestData(), verifier.wrap(fixesAnalyzer), "verify")
+	if len(results) != 1 || len(results[0].Diagnostics) != 1 {
+		t.Fatalf("expected one diagnostic, got %v", results)
+	}
+
+	fixes := results[0].Diagnostics[0].SuggestedFixes
+	if len(fixes) != 1 || fixes[0].Message != "Add comment" {
+		t.Errorf("expected only the compiling fix to be kept, got %v", fixes)
+	}
+	report := out.String()
+	if n := strings.Count(report, "withheld fix"); n != 1 {
+		t.Errorf("expected one withheld fix, got %d", n)
+	}
+	if !strings.Contains(report, `withheld fix "Rename to renamed"`) || !strings.Contains(report, "undefined: target") {
+		t.Errorf("unexpected report:\n%s", report)
+	}
+}
+
+func TestFixVerifierImporters(t *testing.T) {
+	var out bytes.Buffer
+	verifier := newFixVerifier(&out)
+
+	results := analysistest.Run(t, analysistest.TestData(), verifier.wrap(analyzer.Analyzer()), "lib")
+	if len(results) != 1 {
+		t.Fatalf("expected one result, got %d", len(results))
+	}
+	pass := results[0].Pass
+
+	// The chain fix keeps the signature of lib.Render, so the importing package still compiles
+	kept := 0
+	for _, d := range results[0].Diagnostics {
+		for _, fix := range d.SuggestedFixes {
+			pkg, err := checkPackage(pass, fix)
+			if err != nil {
+				t.Fatalf("%s: %v", fix.Message, err)
+			}
+			if err := checkImporter(pkg, "app/app.go"); err != nil {
+				t.Errorf("%s breaks the importing package: %v", fix.Message, err)
+			}
+			kept++
+		}
+	}
+	if kept == 0 {
+		t.Errorf("expected the chain fix to be kept, report:\n%s", out.String())
+	}
+
+	// Renaming lib.Render compiles in lib, but breaks the importing package
+	var render *ast.Ident
+	for _, decl := range pass.Files[0].Decls {
+		if f, ok := decl.(*ast.FuncDecl); ok && f.Name.Name == "Render" {
+			render = f.Name
+		}
+	}
+	rename := analysis.SuggestedFix{
+		Message:   "Rename Render to Show",
+		TextEdits: []analysis.TextEdit{{Pos: render.Pos(), End: render.End(), NewText: []byte("Show")}},
+	}
+	pkg, err := checkPackage(pass, rename)
+	if err != nil {
+		t.Fatalf("expected the renamed package to compile: %v", err)
+	}
+	if err := checkImporter(pkg, "app/app.go"); err == nil {
+		t.Fatal("expected the rename to break the importing package")
+	}
+	if err := checkFix(pass, rename); err == nil || !strings.Contains(err.Error(), "removes exported func lib.Render") {
+		t.Errorf("expected the rename to be withheld, got %v", err)
+	}
+}
+
+// checkImporter type-checks a testdata file of another package against the fixed package lib
+func checkImporter(lib *types.Package, name string) error {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filepath.Join(analysistest.TestData(), "src", name), nil, 0)
+	if err != nil {
+		return err
+	}
+	conf := types.Config{Importer: importerFunc(func(path string) (*types.Package, error) {
+		if path == lib.Path() {
+			return lib, nil
+		}
+		return importer.Default().Import(path)
+	})}
+	_, err = conf.Check(file.Name.Name, fset, []*ast.File{file}, nil)
+	return err
+}
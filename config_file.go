@@ -0,0 +1,184 @@
+package usestruct
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/Truenya/usestruct/pkg/analyzer"
+	"github.com/golangci/plugin-module-register/register"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileNames lists the names of standalone configuration files. A directory may contain
+// at most one of them
+var ConfigFileNames = []string{".usestruct.yml", ".usestruct.yaml", ".usestruct.json"}
+
+// LoadConfig builds the configuration of the standalone command from the configuration files
+// found in dir and its parent directories. The files use the same keys as the golangci-lint
+// settings and are applied on top of DefaultConfig starting from the outermost one, so a file
+// in a subtree overrides the keys it sets and keeps the rest. Lists are replaced, not appended.
+// LoadConfig returns the applied files in that order
+func LoadConfig(dir string) (Config, []string, error) {
+	files, err := findConfigFiles(dir)
+	if err != nil {
+		return Config{}, nil, err
+	}
+
+	settings := make(map[string]any)
+	for _, file := range files {
+		fileSettings, err := readConfigFile(file)
+		if err != nil {
+			return Config{}, nil, err
+		}
+		for key, value := range fileSettings {
+			settings[key] = value
+		}
+	}
+
+	config, err := parseSettings(settings)
+	if err != nil {
+		return Config{}, nil, err
+	}
+	return config, files, nil
+}
+
+// NewWithConfig creates the plugin from a configuration loaded by LoadConfig
+func NewWithConfig(config Config) (register.LinterPlugin, error) {
+	if _, err := config.options(); err != nil {
+		return nil, fmt.Errorf("usestruct: %w", err)
+	}
+	return PluginUsestructModule{config: config}, nil
+}
+
+// NewWithConfigFiles creates the plugin for the standalone command. Each analyzed package is
+// configured by LoadConfig from its own directory, so configuration files in subtrees apply
+// to their packages even when the command runs from the module root. The configuration
+// of dir is loaded up front, so that errors are reported before the analysis starts
+func NewWithConfigFiles(dir string) (register.LinterPlugin, error) {
+	config, _, err := LoadConfig(dir)
+	if err != nil {
+		return nil, err
+	}
+	return PluginUsestructModule{
+		config:  config,
+		configs: &dirConfigs{configs: make(map[string]dirConfig)},
+	}, nil
+}
+
+// dirConfigs caches the configurations of package directories
+type dirConfigs struct {
+	mu      sync.Mutex
+	configs map[string]dirConfig
+}
+
+// dirConfig is the result of LoadConfig for a directory
+type dirConfig struct {
+	config Config
+	err    error
+}
+
+// load returns the configuration of dir, see LoadConfig
+func (c *dirConfigs) load(dir string) (Config, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.configs[dir]
+	if !ok {
+		cached.config, _, cached.err = LoadConfig(dir)
+		c.configs[dir] = cached
+	}
+	return cached.config, cached.err
+}
+
+// options returns the options of the parameter analyzer for a package directory
+func (c *dirConfigs) options(dir string) (analyzer.Options, error) {
+	config, err := c.load(dir)
+	if err != nil {
+		return analyzer.Options{}, err
+	}
+	return config.options()
+}
+
+// longParamsOptions returns the options of the long parameter list analyzer for a package directory
+func (c *dirConfigs) longParamsOptions(dir string) (analyzer.LongParamsOptions, error) {
+	config, err := c.load(dir)
+	if err != nil {
+		return analyzer.LongParamsOptions{}, err
+	}
+	opts, err := config.options()
+	if err != nil {
+		return analyzer.LongParamsOptions{}, err
+	}
+	return config.longParamsOptions(opts.Filter), nil
+}
+
+// findConfigFiles returns the configuration files of dir and its parents, outermost first
+func findConfigFiles(dir string) ([]string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for {
+		var found []string
+		for _, name := range ConfigFileNames {
+			file := filepath.Join(dir, name)
+			info, err := os.Stat(file)
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					continue
+				}
+				return nil, err
+			}
+			if !info.IsDir() {
+				found = append(found, file)
+			}
+		}
+		if len(found) > 1 {
+			return nil, fmt.Errorf("%s: several configuration files in one directory: %s",
+				dir, strings.Join(found, ", "))
+		}
+		files = append(files, found...)
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	slices.Reverse(files)
+	return files, nil
+}
+
+// readConfigFile decodes and validates a configuration file. Errors name the file and the key
+func readConfigFile(file string) (map[string]any, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var settings map[string]any
+	if filepath.Ext(file) == ".json" {
+		err = json.Unmarshal(data, &settings)
+	} else {
+		err = yaml.Unmarshal(data, &settings)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+
+	if err := validateSettings(settings, reflect.TypeOf(Config{}), ""); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	return settings, nil
+}
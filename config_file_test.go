@@ -0,0 +1,177 @@
+package usestruct
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+// writeFile creates a file with its parent directories
+func writeFile(t *testing.T, name, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, ".usestruct.yml"), `
+min_required_params: 3
+max_params: 6
+exclude_files:
+  - '*.pb.go'
+`)
+	writeFile(t, filepath.Join(root, "services", "billing", ".usestruct.json"), `{
+  "max_params": 8,
+  "exclude_files": ["*_mock.go"],
+  "skip_tests": true
+}`)
+	dir := filepath.Join(root, "services", "billing", "api")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	config, files, err := LoadConfig(dir)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	wantFiles := []string{
+		filepath.Join(root, ".usestruct.yml"),
+		filepath.Join(root, "services", "billing", ".usestruct.json"),
+	}
+	if !reflect.DeepEqual(files, wantFiles) {
+		t.Errorf("LoadConfig() files = %v, want %v", files, wantFiles)
+	}
+
+	want := DefaultConfig()
+	want.MinRequiredParams = 3
+	want.MaxParams = 8
+	want.ExcludeFiles = []string{"*_mock.go"}
+	want.SkipTests = true
+	if !reflect.DeepEqual(config, want) {
+		t.Errorf("LoadConfig() = %+v, want %+v", config, want)
+	}
+
+	// Outside the subtree only the outer file applies
+	config, _, err = LoadConfig(root)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if config.MaxParams != 6 || config.SkipTests {
+		t.Errorf("LoadConfig(root) = %+v, want max_params 6 without skip_tests", config)
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expected string
+	}{
+		{
+			name:     "typo",
+			files:    map[string]string{".usestruct.yml": "max_parms: 4\n"},
+			expected: ".usestruct.yml: max_parms: unknown key, did you mean max_params?",
+		},
+		{
+			name:     "range",
+			files:    map[string]string{".usestruct.json": `{"max_recursion_depth": 0}`},
+			expected: ".usestruct.json: max_recursion_depth: must be at least 1, got 0",
+		},
+		{
+			name:     "syntax",
+			files:    map[string]string{".usestruct.json": `{"max_params": }`},
+			expected: ".usestruct.json: invalid character",
+		},
+		{
+			name:     "several files",
+			files:    map[string]string{".usestruct.yml": "", ".usestruct.json": "{}"},
+			expected: "several configuration files in one directory",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				writeFile(t, filepath.Join(dir, name), content)
+			}
+			_, _, err := LoadConfig(dir)
+			if err == nil {
+				t.Fatal("LoadConfig() error = nil")
+			}
+			if !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("LoadConfig() error = %q, want it to contain %q", err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNewWithConfigFiles(t *testing.T) {
+	root := t.TempDir()
+	src := `
+func render(title, header, body string) {
+	draw(title, header, body)
+}
+
+func draw(t, h, b string) {
+	_ = t + h + b
+}
+
+func wide(a, b, c, d, e int) {}
+`
+	writeFile(t, filepath.Join(root, "go.mod"), "module m\n\ngo 1.23\n")
+	writeFile(t, filepath.Join(root, ".usestruct.yml"), "max_params: 4\n")
+	writeFile(t, filepath.Join(root, "app.go"), "package app\n"+src)
+	// The subtree raises both thresholds, so nothing is reported in it
+	writeFile(t, filepath.Join(root, "legacy", ".usestruct.yml"), "min_group_size: 4\nmax_params: 6\n")
+	writeFile(t, filepath.Join(root, "legacy", "legacy.go"), "package legacy\n"+src)
+
+	// The command runs from the module root
+	plugin, err := NewWithConfigFiles(root)
+	if err != nil {
+		t.Fatalf("NewWithConfigFiles() error = %v", err)
+	}
+	analyzers, err := plugin.BuildAnalyzers()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mode := packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps |
+		packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedTypesSizes
+	pkgs, err := packages.Load(&packages.Config{Mode: mode, Dir: root}, "./...")
+	if err != nil {
+		t.Fatal(err)
+	}
+	graph, err := checker.Analyze(analyzers, pkgs, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(map[string][]string)
+	for _, act := range graph.Roots {
+		if act.Err != nil {
+			t.Fatalf("%s: %v", act.Package.PkgPath, act.Err)
+		}
+		for _, d := range act.Diagnostics {
+			got[act.Package.PkgPath] = append(got[act.Package.PkgPath], d.Message)
+		}
+	}
+
+	if len(got["m"]) != 2 {
+		t.Errorf("expected the chain and the long parameter list in m, got %q", got["m"])
+	}
+	if len(got["m/legacy"]) != 0 {
+		t.Errorf("expected the subtree configuration to apply to m/legacy, got %q", got["m/legacy"])
+	}
+}
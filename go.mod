@@ -5,6 +5,7 @@ go 1.23.4
 require (
 	github.com/golangci/plugin-module-register v0.1.1
 	golang.org/x/tools v0.29.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -3,7 +3,9 @@ package analyzer
 import (
 	"fmt"
 	"go/ast"
+	"go/token"
 	"go/types"
+	"reflect"
 	"slices"
 	"sort"
 	"strings"
@@ -29,32 +31,256 @@ type ParamAnalyzer struct {
 	minRequiredParams int
 	// maxRecursionDepth определяет максимальную глубину рекурсии при анализе цепочки вызовов
 	maxRecursionDepth int
+	// minGroupSize определяет минимальное количество параметров, которые должны передаваться
+	// через всю цепочку
+	minGroupSize int
+	// minChainLength определяет минимальное количество функций в цепочке, о которой сообщается
+	minChainLength int
+	// minClumpSize определяет минимальный размер повторяющейся группы параметров
+	minClumpSize int
+	// minClumpOccurrences определяет, в скольких сигнатурах должна встретиться группа параметров
+	minClumpOccurrences int
+	// callSites хранит места вызова объявленных в анализируемом коде функций
+	callSites map[*ast.FuncDecl][]*ast.CallExpr
+	// minOptionalParams определяет, сколько необязательных на вид параметров
+	// должно быть у конструктора, чтобы предложить функциональные опции
+	minOptionalParams int
+	// minFlagParams определяет минимальное количество логических параметров (или небольших
+	// перечислений), при котором предлагается структура флагов
+	minFlagParams int
+	// pointerThreshold определяет размер структуры в байтах, начиная с которого
+	// ее рекомендуется передавать по указателю
+	pointerThreshold int
+	// compatWrappers включает сохранение старой сигнатуры экспортированных корневых функций
+	compatWrappers bool
+	// preferredFix определяет исправление, которое предлагается для цепочки в первую очередь
+	preferredFix FixKind
+	// directives хранит директивы подавления пакета
+	directives []*directive
+	// ignoredFuncs хранит функции, исключенные из цепочек директивами
+	ignoredFuncs map[*ast.FuncDecl]*directive
+	// ignoredCalls хранит вызовы, на которых директивы обрывают цепочки
+	ignoredCalls map[*ast.CallExpr]*directive
+	// fixedFuncs хранит функции, сигнатура которых не должна меняться
+	fixedFuncs set[*ast.FuncDecl]
+	// filter определяет исключенные из анализа пакеты, файлы и функции
+	filter Filter
+	// generated хранит сгенерированные файлы пакета
+	generated set[*token.File]
+	// generatedFuncs хранит функции сгенерированных файлов, которые остаются звеньями цепочек
+	generatedFuncs set[*ast.FuncDecl]
 }
 
-// run выполняет анализ кода
-func (m *ParamAnalyzer) run(pass *analysis.Pass) (any, error) {
+// collect собирает объявления функций пакета, места их вызова и цепочки вызовов.
+// Цепочки продолжаются в функции других пакетов по их фактам
+func (m *ParamAnalyzer) collect(pass *analysis.Pass) error {
 	m.info = pass.TypesInfo
 	inspector, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 	if !ok {
-		return nil, fmt.Errorf("failed to get inspector from pass")
+		return fmt.Errorf("failed to get inspector from pass")
 	}
 
 	declFilter := []ast.Node{
 		(*ast.FuncDecl)(nil),
 	}
 
-	inspector.Nodes(declFilter, m.addNodeDecls())
+	for _, file := range pass.Files {
+		if ast.IsGenerated(file) {
+			m.generated.Add(pass.Fset.File(file.Pos()))
+		}
+	}
+	m.collectDirectives(pass)
+	inspector.Nodes(declFilter, m.addNodeDecls(pass))
 	inspector.Nodes(declFilter, m.getProcessSingleFuncDeclCallback(pass))
+	return nil
+}
+
+// run сообщает о цепочках и группах параметров, собранных анализатором цепочек
+func run(pass *analysis.Pass, chains *analysis.Analyzer) (any, error) {
+	m, ok := pass.ResultOf[chains].(*ParamAnalyzer)
+	if !ok {
+		return nil, fmt.Errorf("failed to get call chains from pass")
+	}
+	m.info = pass.TypesInfo
+
+	// Директивы подавляют диагностики в функциях и вызовах, к которым относятся.
+	// В сгенерированных файлах нет диагностик, и исправления их не меняют
+	report := pass.Report
+	pass.Report = func(d analysis.Diagnostic) {
+		if m.hidden(pass, d.Pos) {
+			return
+		}
+		d.SuggestedFixes = slices.DeleteFunc(d.SuggestedFixes, func(fix analysis.SuggestedFix) bool {
+			return m.editsGenerated(pass, fix)
+		})
+		report(d)
+	}
 
 	// Фильтруем только максимальные цепочки (не вложенные)
+	result := &Result{}
 	maxChains := filterMaxChains(m.results)
 	for _, res := range maxChains {
-		if res.msg != "" && res.leafFunc != nil {
-			pass.Reportf(res.leafFunc.Pos(), res.msg)
+		if res.msg == "" || res.leafFunc == nil || len(res.callStack) < m.minChainLength {
+			continue
+		}
+		// Для цепочек из одних флагов предлагается только структура флагов, см. reportBoolFlags
+		if m.onlyFlags(pass, res) {
+			continue
+		}
+		if pos, ok := m.reportChain(pass, res); ok && !m.hidden(pass, pos) {
+			result.Chains = append(result.Chains, m.chainOf(pass, res, pos))
+		}
+	}
+
+	m.reportClumps(pass, maxChains)
+	m.reportConstructorOptions(pass)
+	result.Chains = append(result.Chains, m.reportBoolFlags(pass, maxChains)...)
+
+	pass.Report = report
+	m.reportUnusedDirectives(pass)
+
+	return result, nil
+}
+
+// editsGenerated проверяет, что исправление меняет сгенерированный файл
+func (m *ParamAnalyzer) editsGenerated(pass *analysis.Pass, fix analysis.SuggestedFix) bool {
+	return slices.ContainsFunc(fix.TextEdits, func(edit analysis.TextEdit) bool {
+		return m.generated.Has(pass.Fset.File(edit.Pos))
+	})
+}
+
+// hidden проверяет, что диагностика в позиции не сообщается: она находится
+// в сгенерированном файле или подавлена директивой
+func (m *ParamAnalyzer) hidden(pass *analysis.Pass, pos token.Pos) bool {
+	return m.generated.Has(pass.Fset.File(pos)) || m.suppressed(pos)
+}
+
+// reportChain сообщает о найденной цепочке. Для цепочек методов одного типа
+// вместо новой структуры предлагается перенести параметры в поля получателя,
+// для цепочек свободных функций предлагаются исправления. Для цепочек, которые
+// продолжаются в других пакетах, рекомендуется пакет для структуры.
+// Возвращает позицию диагностики, если о цепочке сообщено
+func (m *ParamAnalyzer) reportChain(pass *analysis.Pass, res chainResult) (token.Pos, bool) {
+	m.ma.RLock()
+	root, ok := m.all[res.callStack[0]]
+	m.ma.RUnlock()
+	if !ok || !m.filter.reportable(root) {
+		return token.NoPos, false
+	}
+
+	// Функции с фиксированной сигнатурой остаются в цепочке, но не исправляются
+	part, related, ok := m.withoutFixed(res)
+	if !ok {
+		return token.NoPos, false
+	}
+
+	if d, ok := m.receiverDiagnostic(pass, res, part); ok {
+		d.Related = related
+		pass.Report(d)
+		return d.Pos, true
+	}
+
+	// Для обобщенной группы размер неизвестен: нет рекомендации по размещению и исправлений
+	layout, sized := m.layoutOf(pass, m.rootFields(part))
+	msg := res.msg
+	if sized {
+		msg += ", " + layout.String()
+	}
+	// Цепочке через несколько пакетов нужен пакет, который все они могут импортировать
+	if len(part.pkgs) > 0 {
+		if advice, ok := m.placement(pass, part); ok {
+			msg += ", " + advice
+		}
+	}
+	d := analysis.Diagnostic{
+		Pos:     part.leafFunc.Pos(),
+		Message: msg,
+		Related: related,
+	}
+	// Исправления одной цепочки меняют один и тот же код, поэтому предлагается только одно:
+	// предпочитаемое, а если его построить нельзя, то другое
+	if sized {
+		builders := []func(*analysis.Pass, chainResult, structLayout) (analysis.SuggestedFix, bool){
+			m.paramStructFix,
+			m.methodObjectFix,
+		}
+		if m.preferredFix == FixMethodObject {
+			slices.Reverse(builders)
+		}
+		for _, build := range builders {
+			if fix, ok := build(pass, part, layout); ok && !m.editsGenerated(pass, fix) {
+				d.SuggestedFixes = append(d.SuggestedFixes, fix)
+				break
+			}
+		}
+	}
+	pass.Report(d)
+	return d.Pos, true
+}
+
+// withoutFixed возвращает самую длинную часть цепочки без функций с фиксированной сигнатурой
+// и функций сгенерированных файлов.
+// О цепочке сообщается на последней функции этой части, и исправления меняют только ее.
+// Для функций с фиксированной сигнатурой возвращается связанная информация.
+// Возвращает false, если в цепочке нет функций пакета, которые можно исправить
+func (m *ParamAnalyzer) withoutFixed(res chainResult) (chainResult, []analysis.RelatedInformation, bool) {
+	var related []analysis.RelatedInformation
+	var best, cur []string
+	var bestLeaf, curLeaf *ast.FuncDecl
+	for _, k := range res.callStack {
+		m.ma.RLock()
+		f, ok := m.all[k]
+		m.ma.RUnlock()
+		if ok && m.fixedFuncs.Has(f) {
+			related = append(related, analysis.RelatedInformation{
+				Pos:     f.Pos(),
+				Message: "chain broken by fixed signature of " + k,
+			})
+		} else if ok && m.generatedFuncs.Has(f) {
+			related = append(related, analysis.RelatedInformation{
+				Pos:     f.Pos(),
+				Message: "chain broken by generated function " + k,
+			})
+		}
+		if !ok || m.fixedFuncs.Has(f) || m.generatedFuncs.Has(f) {
+			cur = nil
+			continue
+		}
+		cur = append(cur, k)
+		curLeaf = f
+		if len(cur) > len(best) {
+			best, bestLeaf = slices.Clone(cur), curLeaf
 		}
 	}
 
-	return nil, nil
+	if len(related) == 0 {
+		return res, nil, true
+	}
+	if len(best) == 0 {
+		return chainResult{}, nil, false
+	}
+	return chainResult{
+		callStack: best,
+		msg:       res.msg,
+		leafFunc:  bestLeaf,
+		args:      res.args,
+	}, related, true
+}
+
+// rootFields возвращает поля будущей структуры в порядке параметров корневой функции цепочки.
+// Если в корне не все параметры группы именованы, используются параметры листа
+func (m *ParamAnalyzer) rootFields(res chainResult) []structField {
+	if len(res.callStack) > 0 {
+		m.ma.RLock()
+		root, ok := m.all[res.callStack[0]]
+		m.ma.RUnlock()
+		if ok {
+			if fields := m.groupFields(root, res.args); len(fields) == totalParams(res.args) {
+				return fields
+			}
+		}
+	}
+	return m.groupFields(res.leafFunc, res.args)
 }
 
 // funcDeclToKey преобразует объявление функции в ключ
@@ -110,27 +336,25 @@ func (m *ParamAnalyzer) callExprToKey(f *ast.CallExpr) (string, bool) {
 	return "", false
 }
 
-func (m *ParamAnalyzer) addNodeDecls() func(node ast.Node, push bool) bool {
+func (m *ParamAnalyzer) addNodeDecls(pass *analysis.Pass) func(node ast.Node, push bool) bool {
 	return func(node ast.Node, push bool) bool {
 		if !push {
 			return true
 		}
 
 		funcDecl := node.(*ast.FuncDecl)
-		params := funcDecl.Type.Params.List
-		totalParams := 0
-		for _, param := range params {
-			if len(param.Names) > 0 {
-				totalParams += len(param.Names)
-			} else {
-				totalParams++
-			}
-		}
-		if totalParams < m.minRequiredParams {
+		if countParams(funcDecl.Type.Params) < m.minRequiredParams {
 			return true
 		}
 
+		// Исключенные функции не становятся звеньями цепочек
 		k := m.funcDeclToKey(funcDecl)
+		if m.excluded(pass, funcDecl, k) {
+			return true
+		}
+		if m.generated.Has(pass.Fset.File(funcDecl.Pos())) {
+			m.generatedFuncs.Add(funcDecl)
+		}
 		m.ma.Lock()
 		m.all[k] = funcDecl
 		m.ma.Unlock()
@@ -138,10 +362,37 @@ func (m *ParamAnalyzer) addNodeDecls() func(node ast.Node, push bool) bool {
 	}
 }
 
+// excluded проверяет, что функция исключена из анализа настройками фильтра
+// или находится в сгенерированном файле, который не нужно обходить
+func (m *ParamAnalyzer) excluded(pass *analysis.Pass, f *ast.FuncDecl, k string) bool {
+	if m.filter.skipFunc(pass, f, k) {
+		return true
+	}
+	return m.filter.Generated == GeneratedIgnore && m.generated.Has(pass.Fset.File(f.Pos()))
+}
+
+// addCallSites запоминает вызовы функций, объявленных в анализируемом коде
+func (m *ParamAnalyzer) addCallSites(calls []*ast.CallExpr) {
+	for _, callExpr := range calls {
+		k, ok := m.callExprToKey(callExpr)
+		if !ok {
+			continue
+		}
+
+		m.ma.Lock()
+		if f, ok := m.all[k]; ok {
+			m.callSites[f] = append(m.callSites[f], callExpr)
+		}
+		m.ma.Unlock()
+	}
+}
+
 type chainResult struct {
 	callStack []string
 	msg       string
-	leafFunc  *ast.FuncDecl // конечная функция цепочки
+	leafFunc  *ast.FuncDecl  // конечная функция цепочки в пакете
+	args      map[string]int // типы параметров, передаваемых через всю цепочку
+	pkgs      []string       // пути пакетов функций цепочки, если она продолжается в других пакетах
 }
 
 func (m *ParamAnalyzer) recurseCheckDeep(pass *analysis.Pass, currentFunc *ast.FuncDecl, args map[string]int, depth int, callStack []string) chainResult {
@@ -184,7 +435,7 @@ func (m *ParamAnalyzer) recurseCheckDeep(pass *analysis.Pass, currentFunc *ast.F
 		}
 	}
 
-	if totalParams(intersectedArgs) < 3 {
+	if totalParams(intersectedArgs) < m.minGroupSize {
 		return chainResult{}
 	}
 
@@ -199,24 +450,18 @@ func (m *ParamAnalyzer) recurseCheckDeep(pass *analysis.Pass, currentFunc *ast.F
 
 	// Если нет вызовов и это конечная функция в цепочке
 	if len(calls) == 0 {
-		argsStr := make([]string, 0, len(intersectedArgs))
-		for t, n := range intersectedArgs {
-			for range n {
-				argsStr = append(argsStr, t)
-			}
-		}
-		sort.Strings(argsStr)
 		callStackStr := strings.Join(newCallStack, " -> ")
-		msg := fmt.Sprintf("make struct with arguments: %s, for call stack: %s", strings.Join(argsStr, ", "), callStackStr)
-		return chainResult{
+		msg := fmt.Sprintf("make struct with arguments: %s, for call stack: %s", formatArgs(intersectedArgs), callStackStr)
+		return m.cutIgnored(currentFunc, chainResult{
 			callStack: newCallStack,
 			msg:       msg,
 			leafFunc:  currentFunc,
-		}
+			args:      intersectedArgs,
+		})
 	}
 
 	// Для каждого вызова создаем отдельную цепочку
-	chains := m.getChains(pass, calls, newCallStack, intersectedArgs, depth)
+	chains := m.getChains(pass, currentFunc, calls, newCallStack, intersectedArgs, depth)
 	if len(chains) <= 0 {
 		return chainResult{}
 	}
@@ -229,12 +474,39 @@ func (m *ParamAnalyzer) recurseCheckDeep(pass *analysis.Pass, currentFunc *ast.F
 		}
 	}
 
-	return maxResult
+	return m.cutIgnored(currentFunc, maxResult)
 }
 
-func (m *ParamAnalyzer) getChains(pass *analysis.Pass, calls []*ast.CallExpr, newCallStack []string, intersectedArgs map[string]int, depth int) []chainResult {
+// cutIgnored обрывает цепочку, которая проходит через функцию, исключенную директивой,
+// и отмечает директиву использованной
+func (m *ParamAnalyzer) cutIgnored(f *ast.FuncDecl, res chainResult) chainResult {
+	if d, ok := m.ignoredFuncs[f]; ok && res.msg != "" {
+		d.used = true
+		return chainResult{}
+	}
+	return res
+}
+
+// ignoredCall проверяет, что директива обрывает цепочку на вызове. Директива отмечается
+// использованной, если без нее через вызов прошла бы цепочка
+func (m *ParamAnalyzer) ignoredCall(callExpr *ast.CallExpr, res chainResult) bool {
+	d, ok := m.ignoredCalls[callExpr]
+	if ok && res.msg != "" {
+		d.used = true
+	}
+	return ok
+}
+
+func (m *ParamAnalyzer) getChains(pass *analysis.Pass, caller *ast.FuncDecl, calls []*ast.CallExpr, newCallStack []string, intersectedArgs map[string]int, depth int) []chainResult {
 	chains := make([]chainResult, 0, len(calls))
 	for _, callExpr := range calls {
+		if res, ok := m.externalChain(pass, caller, callExpr, newCallStack, intersectedArgs); ok {
+			if !m.ignoredCall(callExpr, res) {
+				chains = append(chains, res)
+			}
+			continue
+		}
+
 		calledKey, ok := m.callExprToKey(callExpr)
 		if !ok {
 			continue
@@ -260,7 +532,7 @@ func (m *ParamAnalyzer) getChains(pass *analysis.Pass, calls []*ast.CallExpr, ne
 		}
 
 		res := m.recurseCheckDeep(pass, calledFunc, intersectedArgs, depth+1, newCallStack)
-		if res.msg == "" {
+		if res.msg == "" || m.ignoredCall(callExpr, res) {
 			continue
 		}
 
@@ -289,6 +561,18 @@ func (m *ParamAnalyzer) initArgsMap(params []*ast.Field) map[string]int {
 	return args
 }
 
+// formatArgs возвращает отсортированный список типов параметров с учетом их количества
+func formatArgs(args map[string]int) string {
+	argsStr := make([]string, 0, len(args))
+	for t, n := range args {
+		for range n {
+			argsStr = append(argsStr, t)
+		}
+	}
+	sort.Strings(argsStr)
+	return strings.Join(argsStr, ", ")
+}
+
 // Считает общее количество параметров (по всем типам)
 func totalParams(args map[string]int) int {
 	sum := 0
@@ -298,23 +582,114 @@ func totalParams(args map[string]int) int {
 	return sum
 }
 
+// FixKind определяет вид исправления цепочки
+type FixKind int
+
+const (
+	// FixParamStruct вводит структуру параметров во всех функциях цепочки
+	FixParamStruct FixKind = iota
+	// FixMethodObject делает функции цепочки методами объекта с группой параметров
+	FixMethodObject
+)
+
+// Options описывает настройки анализатора параметров
+type Options struct {
+	// MinRequiredParams минимальное количество параметров функции для анализа
+	MinRequiredParams int
+	// MaxRecursionDepth максимальная глубина рекурсии при анализе цепочки вызовов
+	MaxRecursionDepth int
+	// MinGroupSize минимальное количество параметров, передаваемых через всю цепочку
+	MinGroupSize int
+	// MinChainLength минимальное количество функций в цепочке, о которой сообщается
+	MinChainLength int
+	// MinClumpSize минимальный размер повторяющейся группы параметров
+	MinClumpSize int
+	// MinClumpOccurrences минимальное количество сигнатур, в которых встречается группа
+	MinClumpOccurrences int
+	// MinOptionalParams минимальное количество необязательных на вид параметров конструктора
+	MinOptionalParams int
+	// MinFlagParams минимальное количество логических параметров (или небольших перечислений)
+	MinFlagParams int
+	// PointerThreshold размер структуры в байтах, больше которого ее следует передавать по указателю
+	PointerThreshold int
+	// CompatWrappers сохраняет старую сигнатуру экспортированной корневой функции
+	// в виде устаревшей обертки при введении структуры параметров
+	CompatWrappers bool
+	// PreferredFix исправление, которое предлагается для цепочки, если его можно построить.
+	// Иначе предлагается другое
+	PreferredFix FixKind
+	// Filter исключает пакеты, файлы и функции из анализа
+	Filter Filter
+	// Overrides переопределяют пороги анализа цепочек для отдельных пакетов
+	Overrides []Override
+	// ForDir, если задана, возвращает настройки пакета по каталогу его файлов,
+	// например из файлов конфигурации этого каталога. Overrides возвращенных настроек
+	// применяются к ним как обычно
+	ForDir func(dir string) (Options, error)
+}
+
+// DefaultOptions возвращает настройки анализатора по умолчанию
+func DefaultOptions() Options {
+	return Options{
+		MinRequiredParams:   2,
+		MaxRecursionDepth:   10,
+		MinGroupSize:        3,
+		MinChainLength:      2,
+		MinClumpSize:        3,
+		MinClumpOccurrences: 3,
+		MinOptionalParams:   2,
+		MinFlagParams:       2,
+		PointerThreshold:    80,
+	}
+}
+
 // Analyzer создает новый анализатор параметров с значениями по умолчанию
 func Analyzer() *analysis.Analyzer {
-	return AnalyzerWithConfig(2, 10) // default values
+	return AnalyzerWithOptions(DefaultOptions())
 }
 
 // AnalyzerWithConfig создает новый анализатор параметров с указанными конфигурационными значениями
 func AnalyzerWithConfig(minRequiredParams, maxRecursionDepth int) *analysis.Analyzer {
-	m := &ParamAnalyzer{
-		all:               make(map[string]*ast.FuncDecl),
-		minRequiredParams: minRequiredParams,
-		maxRecursionDepth: maxRecursionDepth,
-	}
+	opts := DefaultOptions()
+	opts.MinRequiredParams = minRequiredParams
+	opts.MaxRecursionDepth = maxRecursionDepth
+	return AnalyzerWithOptions(opts)
+}
+
+// AnalyzerWithOptions создает новый анализатор параметров с указанными настройками
+func AnalyzerWithOptions(opts Options) *analysis.Analyzer {
+	chains := chainsAnalyzer(opts)
 	return &analysis.Analyzer{
-		Name:     "paramStructAnalyzer",
-		Doc:      "suggests to make struct for group of arguments passed through function chain",
-		Run:      m.run,
-		Requires: []*analysis.Analyzer{inspect.Analyzer},
+		Name:       "paramStructAnalyzer",
+		Doc:        "suggests to make struct for group of arguments passed through function chain",
+		Run:        func(pass *analysis.Pass) (any, error) { return run(pass, chains) },
+		Requires:   []*analysis.Analyzer{inspect.Analyzer, chains},
+		ResultType: reflect.TypeOf((*Result)(nil)),
+	}
+}
+
+// newParamAnalyzer создает состояние анализатора для одного пакета
+func newParamAnalyzer(opts Options) *ParamAnalyzer {
+	return &ParamAnalyzer{
+		all:                 make(map[string]*ast.FuncDecl),
+		minRequiredParams:   opts.MinRequiredParams,
+		maxRecursionDepth:   opts.MaxRecursionDepth,
+		minGroupSize:        opts.MinGroupSize,
+		minChainLength:      opts.MinChainLength,
+		minClumpSize:        opts.MinClumpSize,
+		minClumpOccurrences: opts.MinClumpOccurrences,
+		callSites:           make(map[*ast.FuncDecl][]*ast.CallExpr),
+		minOptionalParams:   opts.MinOptionalParams,
+		minFlagParams:       opts.MinFlagParams,
+		pointerThreshold:    opts.PointerThreshold,
+		compatWrappers:      opts.CompatWrappers,
+		preferredFix:        opts.PreferredFix,
+		ignoredFuncs:        make(map[*ast.FuncDecl]*directive),
+		ignoredCalls:        make(map[*ast.CallExpr]*directive),
+		fixedFuncs:          NewSet[*ast.FuncDecl](),
+		filter:              opts.Filter,
+		generated:           NewSet[*token.File](),
+		generatedFuncs:      NewSet[*ast.FuncDecl](),
 	}
 }
 
@@ -325,21 +700,6 @@ func (m *ParamAnalyzer) getProcessSingleFuncDeclCallback(pass *analysis.Pass) fu
 		}
 
 		funcDecl := node.(*ast.FuncDecl)
-		params := funcDecl.Type.Params.List
-		totalParams := 0
-		for _, param := range params {
-			if len(param.Names) > 0 {
-				totalParams += len(param.Names)
-			} else {
-				totalParams++
-			}
-		}
-		if totalParams < m.minRequiredParams {
-			return true
-		}
-
-		k := m.funcDeclToKey(funcDecl)
-		args := m.initArgsMap(params)
 
 		// Собираем все вызовы в текущей функции
 		var calls []*ast.CallExpr
@@ -350,8 +710,28 @@ func (m *ParamAnalyzer) getProcessSingleFuncDeclCallback(pass *analysis.Pass) fu
 			return true
 		})
 
+		// Места вызова нужны независимо от количества параметров вызывающей функции
+		m.addCallSites(calls)
+
+		params := funcDecl.Type.Params.List
+		if countParams(funcDecl.Type.Params) < m.minRequiredParams {
+			return true
+		}
+
+		k := m.funcDeclToKey(funcDecl)
+		if m.excluded(pass, funcDecl, k) {
+			return true
+		}
+		args := m.initArgsMap(params)
+
 		// Для каждого вызова создаем отдельную цепочку
 		for _, callExpr := range calls {
+			// Вызов функции другого пакета продолжает цепочку по ее факту
+			if res, ok := m.externalChain(pass, funcDecl, callExpr, []string{k}, args); ok {
+				m.addResult(funcDecl, callExpr, res)
+				continue
+			}
+
 			lowerK, ok := m.callExprToKey(callExpr)
 			if !ok {
 				continue
@@ -365,7 +745,7 @@ func (m *ParamAnalyzer) getProcessSingleFuncDeclCallback(pass *analysis.Pass) fu
 			// Передаем стек, начинающийся с текущей функции (корня)
 			res := m.recurseCheckDeep(pass, lowerFunc, args, 1, []string{k})
 			if res.msg != "" && res.leafFunc != nil {
-				m.results = append(m.results, res)
+				m.addResult(funcDecl, callExpr, res)
 			}
 		}
 
@@ -373,6 +753,17 @@ func (m *ParamAnalyzer) getProcessSingleFuncDeclCallback(pass *analysis.Pass) fu
 	}
 }
 
+// addResult запоминает цепочку, которая начинается с вызова в корневой функции,
+// если директивы не исключают корень или не обрывают цепочку на вызове
+func (m *ParamAnalyzer) addResult(root *ast.FuncDecl, callExpr *ast.CallExpr, res chainResult) {
+	if m.ignoredCall(callExpr, res) {
+		return
+	}
+	if res = m.cutIgnored(root, res); res.msg != "" {
+		m.results = append(m.results, res)
+	}
+}
+
 // filterMaxChains оставляет только уникальные максимальные цепочки (без вложенных и дублей по callStack)
 func filterMaxChains(chains []chainResult) []chainResult {
 	if len(chains) == 0 {
@@ -1,8 +1,13 @@
 package analyzer
 
 import (
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
 	"testing"
 
+	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/analysistest"
 )
 
@@ -15,3 +20,197 @@ func TestIntegrationParamStructAnalyzer2(t *testing.T) {
 	testdata := analysistest.TestData()
 	analysistest.Run(t, testdata, Analyzer(), "testcase2")
 }
+
+func TestIntegrationParamStructAnalyzerClumps(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, Analyzer(), "clumps")
+}
+
+func TestIntegrationLongParamsAnalyzer(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, LongParamsAnalyzer(LongParamsOptions{
+		Max:             5,
+		MaxExported:     4,
+		MaxMethods:      3,
+		MaxConstructors: 7,
+	}), "longparams")
+}
+
+func TestIntegrationParamStructAnalyzerConstructors(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, Analyzer(), "constructors")
+}
+
+func TestIntegrationParamStructAnalyzerFlags(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, Analyzer(), "flags")
+}
+
+func TestIntegrationParamStructAnalyzerReceiver(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, Analyzer(), "receiver")
+}
+
+func TestIntegrationParamStructAnalyzerMethodObject(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, Analyzer(), "methodobject")
+}
+
+func TestIntegrationParamStructAnalyzerPreferredFix(t *testing.T) {
+	testdata := analysistest.TestData()
+	opts := DefaultOptions()
+	opts.PreferredFix = FixMethodObject
+	analysistest.RunWithSuggestedFixes(t, testdata, AnalyzerWithOptions(opts), "preferredfix")
+}
+
+func TestIntegrationParamStructAnalyzerNames(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, Analyzer(), "names")
+}
+
+func TestIntegrationParamStructAnalyzerDirectives(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, Analyzer(), "directives")
+}
+
+func TestIntegrationParamStructAnalyzerFixedSignature(t *testing.T) {
+	testdata := analysistest.TestData()
+	results := analysistest.RunWithSuggestedFixes(t, testdata, Analyzer(), "fixedsig")
+
+	var related []string
+	for _, res := range results {
+		for _, d := range res.Diagnostics {
+			for _, r := range d.Related {
+				related = append(related, r.Message)
+			}
+		}
+	}
+	expected := []string{
+		"chain broken by fixed signature of Callback",
+		"chain broken by fixed signature of onPacket",
+		"chain broken by fixed signature of write",
+	}
+	slices.Sort(related)
+	if !slices.Equal(related, expected) {
+		t.Errorf("related information = %v, want %v", related, expected)
+	}
+}
+
+func TestIntegrationParamStructAnalyzerFilter(t *testing.T) {
+	testdata := analysistest.TestData()
+	opts := DefaultOptions()
+	opts.Filter = Filter{
+		ExcludeFunctions: []*regexp.Regexp{regexp.MustCompile(`^filter\.legacy`)},
+		ExcludePackages:  []string{"filter/gen/..."},
+		ExcludeFiles:     []string{"*_mock.go"},
+		OnlyExported:     true,
+	}
+	analysistest.Run(t, testdata, AnalyzerWithOptions(opts), "filter", "filter/gen")
+}
+
+func TestIntegrationParamStructAnalyzerOverrides(t *testing.T) {
+	testdata := analysistest.TestData()
+	opts := DefaultOptions()
+	opts.Overrides = []Override{
+		{Packages: []string{"overrides/..."}, MinGroupSize: 2},
+		{Packages: []string{"overrides/handlers"}, MinGroupSize: 4, MinChainLength: 3},
+	}
+	analysistest.Run(t, testdata, AnalyzerWithOptions(opts), "overrides/domain", "overrides/handlers")
+}
+
+func TestIntegrationParamStructAnalyzerResult(t *testing.T) {
+	testdata := analysistest.TestData()
+	usestruct := Analyzer()
+	// Последующий анализатор сообщает о цепочках в корневых функциях
+	downstream := &analysis.Analyzer{
+		Name:     "chainRoots",
+		Doc:      "reports roots of usestruct chains",
+		Requires: []*analysis.Analyzer{usestruct},
+		Run: func(pass *analysis.Pass) (any, error) {
+			result := pass.ResultOf[usestruct].(*Result)
+			for _, chain := range result.Chains {
+				names := make([]string, 0, len(chain.Funcs))
+				for _, fn := range chain.Funcs {
+					if fn.PkgPath != pass.Pkg.Path() || fn.Decl == nil {
+						t.Errorf("chain function %s: package %q, declaration %v", fn.Name, fn.PkgPath, fn.Decl)
+					}
+					names = append(names, fn.Name)
+				}
+				params := make([]string, 0, len(chain.Params))
+				for _, p := range chain.Params {
+					params = append(params, p.Name+" "+p.Type.String())
+				}
+				// О цепочке флагов сообщается в корне, об остальных — в последней функции
+				if chain.Pos != chain.Funcs[len(chain.Funcs)-1].Decl.Pos() && chain.Pos != chain.Funcs[0].Decl.Pos() {
+					t.Errorf("chain %v: position is neither at the root nor at the last function", names)
+				}
+				pass.Reportf(chain.Funcs[0].Decl.Pos(), "chain %s: %s", strings.Join(names, " -> "), strings.Join(params, ", "))
+			}
+			return nil, nil
+		},
+	}
+	analysistest.Run(t, testdata, downstream, "result")
+}
+
+func TestIntegrationParamStructAnalyzerGenerated(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, Analyzer(), "generated")
+}
+
+func TestIntegrationParamStructAnalyzerGeneratedTraverse(t *testing.T) {
+	testdata := analysistest.TestData()
+	opts := DefaultOptions()
+	opts.Filter.Generated = GeneratedTraverse
+	results := analysistest.RunWithSuggestedFixes(t, testdata, AnalyzerWithOptions(opts), "generatedtraverse")
+
+	var related []string
+	for _, res := range results {
+		for _, d := range res.Diagnostics {
+			for _, r := range d.Related {
+				related = append(related, r.Message)
+			}
+		}
+	}
+	if expected := []string{"chain broken by generated function genStep"}; !slices.Equal(related, expected) {
+		t.Errorf("related information = %v, want %v", related, expected)
+	}
+}
+
+func TestIntegrationParamStructAnalyzerLayout(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, Analyzer(), "layout")
+}
+
+func TestIntegrationParamStructAnalyzerFieldOrder(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, Analyzer(), "fieldorder")
+}
+
+func TestIntegrationParamStructAnalyzerCompatWrappers(t *testing.T) {
+	testdata := analysistest.TestData()
+	opts := DefaultOptions()
+	opts.CompatWrappers = true
+	analysistest.RunWithSuggestedFixes(t, testdata, AnalyzerWithOptions(opts), "compat")
+}
+
+func TestIntegrationParamStructAnalyzerInterfaces(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, Analyzer(), "interfaces")
+}
+
+func TestIntegrationParamStructAnalyzerRewrite(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, Analyzer(), "rewrite")
+}
+
+func TestIntegrationParamStructAnalyzerCrossPackage(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, Analyzer(), "example.com/crosspkg/api", "example.com/crosspkg/service")
+}
+
+func TestIntegrationParamStructAnalyzerModules(t *testing.T) {
+	// Рабочая область не допускает флаг -mod, который может быть задан в окружении
+	t.Setenv("GOFLAGS", "")
+	testdata := analysistest.TestData()
+	analysistest.Run(t, filepath.Join(testdata, "modules", "app"), Analyzer(), "example.com/app")
+}
@@ -0,0 +1,245 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"slices"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// categoryBoolFlags категория диагностики для групп логических параметров
+const categoryBoolFlags = "bool-flags"
+
+// maxFlagEnumValues максимальное количество констант целочисленного типа,
+// при котором он считается небольшим перечислением флагов
+const maxFlagEnumValues = 8
+
+// maxFlagCallExamples максимальное количество примеров мест вызова в сообщении
+const maxFlagCallExamples = 2
+
+// reportBoolFlags предлагает структуру флагов для цепочек и отдельных функций,
+// которые принимают несколько логических параметров или небольших перечислений.
+// Возвращает цепочки из одних флагов, о которых сообщается только так
+func (m *ParamAnalyzer) reportBoolFlags(pass *analysis.Pass, chains []chainResult) []Chain {
+	if m.minFlagParams <= 0 {
+		return nil
+	}
+
+	var flagChains []Chain
+	reported := NewSet[string]()
+	for _, chain := range chains {
+		root, flags, ok := m.flagChain(pass, chain)
+		if !ok {
+			continue
+		}
+		for _, k := range chain.callStack {
+			reported.Add(k)
+		}
+		if len(flags) == totalParams(chain.args) && !m.hidden(pass, root.Pos()) {
+			flagChains = append(flagChains, m.chainOf(pass, chain, root.Pos()))
+		}
+
+		flagTypes := make([]string, 0, len(flags))
+		for _, p := range flags {
+			flagTypes = append(flagTypes, p.typ)
+		}
+		sort.Strings(flagTypes)
+
+		pass.Report(analysis.Diagnostic{
+			Pos:      root.Pos(),
+			Category: categoryBoolFlags,
+			Message: fmt.Sprintf("make flags struct for arguments: %s, for call stack: %s%s",
+				strings.Join(flagTypes, ", "), strings.Join(chain.callStack, " -> "), m.flagCallExamples(root)),
+		})
+	}
+
+	var funcs []*ast.FuncDecl
+	m.ma.RLock()
+	for k, f := range m.all {
+		// Для конструкторов предлагаются опции, см. reportConstructorOptions
+		if !reported.Has(k) && !isConstructor(f) && inPass(pass, f) && m.filter.reportable(f) {
+			funcs = append(funcs, f)
+		}
+	}
+	m.ma.RUnlock()
+
+	sort.Slice(funcs, func(i, j int) bool {
+		return funcs[i].Pos() < funcs[j].Pos()
+	})
+
+	for _, f := range funcs {
+		flags := m.flagParams(f, nil)
+		if len(flags) < m.minFlagParams {
+			continue
+		}
+
+		params := make([]string, 0, len(flags))
+		for _, p := range flags {
+			params = append(params, p.name+" "+p.typ)
+		}
+
+		pass.Report(analysis.Diagnostic{
+			Pos:      f.Pos(),
+			Category: categoryBoolFlags,
+			Message: fmt.Sprintf("make flags struct for arguments of %s: %s%s",
+				f.Name.Name, strings.Join(params, ", "), m.flagCallExamples(f)),
+		})
+	}
+	return flagChains
+}
+
+// flagChain возвращает корень и флаги цепочки, если для нее предлагается структура флагов
+func (m *ParamAnalyzer) flagChain(pass *analysis.Pass, chain chainResult) (*ast.FuncDecl, []flagParam, bool) {
+	if m.minFlagParams <= 0 || len(chain.callStack) == 0 || len(chain.callStack) < m.minChainLength {
+		return nil, nil, false
+	}
+
+	m.ma.RLock()
+	root, ok := m.all[chain.callStack[0]]
+	m.ma.RUnlock()
+	if !ok || !inPass(pass, root) || !m.filter.reportable(root) {
+		return nil, nil, false
+	}
+
+	flags := m.flagParams(root, chain.args)
+	return root, flags, len(flags) >= m.minFlagParams
+}
+
+// onlyFlags проверяет, что для цепочки предлагается структура флагов и вся ее группа
+// состоит из флагов. О такой цепочке не сообщается как об обычной, чтобы не было двух
+// диагностик об одной и той же структуре
+func (m *ParamAnalyzer) onlyFlags(pass *analysis.Pass, chain chainResult) bool {
+	_, flags, ok := m.flagChain(pass, chain)
+	return ok && len(flags) == totalParams(chain.args)
+}
+
+// flagParam описывает параметр-флаг функции
+type flagParam struct {
+	name string
+	typ  string
+}
+
+// flagParams возвращает параметры-флаги функции. Если передан group,
+// учитываются только параметры, типы которых входят в группу (с учетом количества)
+func (m *ParamAnalyzer) flagParams(f *ast.FuncDecl, group map[string]int) []flagParam {
+	used := make(map[string]int)
+	var flags []flagParam
+	for _, field := range f.Type.Params.List {
+		t := m.info.TypeOf(field.Type)
+		if t == nil || !isFlagType(t) {
+			continue
+		}
+
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{ast.NewIdent("_")}
+		}
+		for _, name := range names {
+			if group != nil {
+				if used[t.String()] >= group[t.String()] {
+					continue
+				}
+				used[t.String()]++
+			}
+			flags = append(flags, flagParam{name: name.Name, typ: t.String()})
+		}
+	}
+	return flags
+}
+
+// flagCallExamples возвращает примеры мест вызова функции, в которых флаги переданы литералами
+func (m *ParamAnalyzer) flagCallExamples(f *ast.FuncDecl) string {
+	m.ma.RLock()
+	calls := m.callSites[f]
+	m.ma.RUnlock()
+
+	var examples []string
+	for _, callExpr := range calls {
+		if len(examples) >= maxFlagCallExamples {
+			break
+		}
+		if !hasLiteralFlag(callExpr.Args) {
+			continue
+		}
+		examples = append(examples, types.ExprString(callExpr))
+	}
+
+	if len(examples) == 0 {
+		return ""
+	}
+	return "; call sites: " + strings.Join(examples, ", ")
+}
+
+// hasLiteralFlag проверяет, что среди аргументов есть литерал true или false
+func hasLiteralFlag(args []ast.Expr) bool {
+	for _, arg := range args {
+		if ident, ok := ast.Unparen(arg).(*ast.Ident); ok && (ident.Name == "true" || ident.Name == "false") {
+			return true
+		}
+	}
+	return false
+}
+
+// isFlagType проверяет, что тип является логическим или небольшим целочисленным перечислением
+func isFlagType(t types.Type) bool {
+	basic, ok := t.Underlying().(*types.Basic)
+	if !ok {
+		return false
+	}
+	if basic.Info()&types.IsBoolean != 0 {
+		return true
+	}
+	if basic.Info()&types.IsInteger == 0 {
+		return false
+	}
+
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+
+	// Перечисление — именованный целочисленный тип с константами в своем пакете,
+	// значения которых заданы как в блоке с iota. Так единицы измерения,
+	// например time.Duration, не считаются перечислениями
+	var values []int64
+	scope := named.Obj().Pkg().Scope()
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if !ok || !types.Identical(c.Type(), named) {
+			continue
+		}
+		v, exact := constant.Int64Val(constant.ToInt(c.Val()))
+		if !exact {
+			return false
+		}
+		values = append(values, v)
+	}
+	return len(values) > 0 && len(values) <= maxFlagEnumValues && iotaValues(values)
+}
+
+// iotaValues проверяет, что различные значения констант идут подряд
+// или являются последовательными степенями двойки (битовые флаги, возможно с нулем)
+func iotaValues(values []int64) bool {
+	slices.Sort(values)
+	values = slices.Compact(values)
+
+	consecutive, powers := true, true
+	for i, v := range values {
+		if i > 0 && v != values[i-1]+1 {
+			consecutive = false
+		}
+		switch {
+		case i == 0 && v == 0:
+		case i == 0 || values[i-1] == 0:
+			powers = powers && v > 0 && v&(v-1) == 0
+		default:
+			powers = powers && v == values[i-1]*2
+		}
+	}
+	return consecutive || powers
+}
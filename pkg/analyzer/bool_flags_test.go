@@ -0,0 +1,51 @@
+package analyzer
+
+import (
+	"go/constant"
+	"go/token"
+	"go/types"
+	"testing"
+)
+
+func TestIsFlagType(t *testing.T) {
+	pkg := types.NewPackage("test", "test")
+	newNamed := func(name string, underlying types.Type, values ...int64) *types.Named {
+		named := types.NewNamed(types.NewTypeName(token.NoPos, pkg, name, nil), underlying, nil)
+		for i, v := range values {
+			pkg.Scope().Insert(types.NewConst(token.NoPos, pkg, name+string(rune('A'+i)), named, constant.MakeInt64(v)))
+		}
+		return named
+	}
+	seq := func(n int) []int64 {
+		values := make([]int64, n)
+		for i := range values {
+			values[i] = int64(i)
+		}
+		return values
+	}
+
+	tests := []struct {
+		name     string
+		typ      types.Type
+		expected bool
+	}{
+		{name: "bool", typ: types.Typ[types.Bool], expected: true},
+		{name: "named bool", typ: newNamed("Flag", types.Typ[types.Bool]), expected: true},
+		{name: "plain int", typ: types.Typ[types.Int], expected: false},
+		{name: "small enum", typ: newNamed("Mode", types.Typ[types.Int], seq(3)...), expected: true},
+		{name: "enum from one", typ: newNamed("Level", types.Typ[types.Int], 1, 2, 3), expected: true},
+		{name: "bit flags", typ: newNamed("Perm", types.Typ[types.Uint8], 0, 1, 2, 4, 8), expected: true},
+		{name: "units", typ: newNamed("Span", types.Typ[types.Int64], 1, 1000, 1000000), expected: false},
+		{name: "named int without constants", typ: newNamed("Count", types.Typ[types.Int]), expected: false},
+		{name: "large enum", typ: newNamed("Code", types.Typ[types.Int], seq(maxFlagEnumValues+1)...), expected: false},
+		{name: "named string", typ: newNamed("Kind", types.Typ[types.String]), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isFlagType(tt.typ); got != tt.expected {
+				t.Errorf("isFlagType(%s) = %v, want %v", tt.typ, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/types"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// chainFact описывает цепочку вызовов, которая начинается с экспортированной функции пакета.
+// Факт позволяет продолжить цепочку из пакетов, которые вызывают эту функцию
+type chainFact struct {
+	// Stack функции цепочки от этой функции до листа в виде pkg.Func или pkg.Type.Method
+	Stack []string
+	// Pkgs пути пакетов функций цепочки
+	Pkgs []string
+	// Args типы параметров, которые передаются через всю цепочку, и их количество
+	Args map[string]int
+}
+
+// AFact отмечает chainFact как факт анализа
+func (*chainFact) AFact() {}
+
+// String возвращает цепочку и типы ее параметров
+func (f *chainFact) String() string {
+	return "chain " + strings.Join(f.Stack, " -> ") + ": " + formatArgs(f.Args)
+}
+
+// chainsAnalyzer создает анализатор, который собирает цепочки вызовов пакета и экспортирует
+// факты цепочек его экспортированных функций для зависимых пакетов. Результатом является
+// состояние ParamAnalyzer, по которому paramStructAnalyzer сообщает о найденных цепочках
+func chainsAnalyzer(opts Options) *analysis.Analyzer {
+	return &analysis.Analyzer{
+		Name: "paramChains",
+		Doc:  "collects chains of calls forwarding groups of arguments and exports them as facts",
+		Run: func(pass *analysis.Pass) (any, error) {
+			// Состояние создается для каждого пакета: из-за фактов анализатор выполняется
+			// и для зависимостей, в том числе параллельно
+			if inGoroot(pass) || inDependency(pass) {
+				m := newParamAnalyzer(opts.forPackage(pass.Pkg.Path()))
+				m.info = pass.TypesInfo
+				return m, nil
+			}
+			pkgOpts, err := opts.forPass(pass)
+			if err != nil {
+				return nil, err
+			}
+			m := newParamAnalyzer(pkgOpts)
+			m.info = pass.TypesInfo
+			if pkgOpts.Filter.skipPackage(pass.Pkg.Path()) {
+				return m, nil
+			}
+			if err := m.collect(pass); err != nil {
+				return nil, err
+			}
+			m.exportChainFacts(pass)
+			return m, nil
+		},
+		Requires:   []*analysis.Analyzer{inspect.Analyzer},
+		ResultType: reflect.TypeOf((*ParamAnalyzer)(nil)),
+		FactTypes:  []analysis.Fact{new(chainFact)},
+	}
+}
+
+// inGoroot проверяет, что пакет относится к стандартной библиотеке. В ней нельзя
+// разместить структуру, поэтому цепочки в нее не продолжаются
+func inGoroot(pass *analysis.Pass) bool {
+	if len(pass.Files) == 0 || build.Default.GOROOT == "" {
+		return false
+	}
+	name := pass.Fset.File(pass.Files[0].Pos()).Name()
+	return strings.HasPrefix(filepath.Clean(name), filepath.Join(build.Default.GOROOT, "src")+string(filepath.Separator))
+}
+
+// inDependency проверяет, что пакет относится к модулю-зависимости, а не к основному
+// модулю или модулю рабочей области: у таких модулей есть версия. Функции зависимости
+// изменить нельзя, поэтому цепочки в нее тоже не продолжаются
+func inDependency(pass *analysis.Pass) bool {
+	return pass.Module != nil && pass.Module.Version != ""
+}
+
+// exportChainFacts экспортирует факты цепочек, которые начинаются с экспортированных функций
+// пакета. Экспортированная функция без вызовов с достаточным количеством параметров
+// сама является цепочкой из одной функции
+func (m *ParamAnalyzer) exportChainFacts(pass *analysis.Pass) {
+	longest := make(map[string]chainResult)
+	for _, res := range m.results {
+		if len(res.callStack) == 0 {
+			continue
+		}
+		if cur, ok := longest[res.callStack[0]]; !ok || len(res.callStack) > len(cur.callStack) {
+			longest[res.callStack[0]] = res
+		}
+	}
+
+	m.ma.RLock()
+	defer m.ma.RUnlock()
+	for k, f := range m.all {
+		if !f.Name.IsExported() || f.Type.TypeParams != nil {
+			continue
+		}
+		fn, ok := m.info.Defs[f.Name].(*types.Func)
+		if !ok || fn.Type().(*types.Signature).RecvTypeParams().Len() > 0 {
+			continue
+		}
+
+		fact := &chainFact{}
+		if res, ok := longest[k]; ok {
+			fact.Stack, fact.Pkgs = m.qualifiedStack(pass, res)
+			fact.Args = res.args
+		} else if args := m.initArgsMap(f.Type.Params.List); !hasCalls(f) && totalParams(args) >= m.minGroupSize {
+			fact.Stack, fact.Pkgs = m.qualifiedStack(pass, chainResult{callStack: []string{k}})
+			fact.Args = args
+		} else {
+			continue
+		}
+		// Функция, исключенная директивой, не продолжает цепочки в других пакетах
+		if d, ok := m.ignoredFuncs[f]; ok {
+			d.used = true
+			continue
+		}
+		pass.ExportObjectFact(fn, fact)
+	}
+}
+
+// qualifiedStack возвращает функции цепочки с именем пакета и пути их пакетов
+func (m *ParamAnalyzer) qualifiedStack(pass *analysis.Pass, res chainResult) ([]string, []string) {
+	stack := make([]string, 0, len(res.callStack))
+	pkgs := make([]string, 0, len(res.callStack))
+	for i, k := range res.callStack {
+		if i < len(res.pkgs) && res.pkgs[i] != pass.Pkg.Path() {
+			// Функции других пакетов пришли из фактов уже с именем пакета
+			stack = append(stack, k)
+			pkgs = append(pkgs, res.pkgs[i])
+			continue
+		}
+		stack = append(stack, pass.Pkg.Name()+"."+k)
+		pkgs = append(pkgs, pass.Pkg.Path())
+	}
+	return stack, pkgs
+}
+
+// hasCalls проверяет, что в теле функции есть вызовы
+func hasCalls(f *ast.FuncDecl) bool {
+	found := false
+	ast.Inspect(f, func(node ast.Node) bool {
+		if _, ok := node.(*ast.CallExpr); ok {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// externalChain продолжает цепочку вызовом функции другого пакета, если для нее есть факт цепочки.
+// Функция caller, из которой выполняется вызов, становится последней функцией цепочки в пакете
+func (m *ParamAnalyzer) externalChain(pass *analysis.Pass, caller *ast.FuncDecl, callExpr *ast.CallExpr, callStack []string, args map[string]int) (chainResult, bool) {
+	if pass.ImportObjectFact == nil {
+		return chainResult{}, false
+	}
+	fn := typeutil.StaticCallee(m.info, callExpr)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg() == pass.Pkg {
+		return chainResult{}, false
+	}
+	var fact chainFact
+	if !pass.ImportObjectFact(fn, &fact) {
+		return chainResult{}, false
+	}
+
+	intersectedArgs := make(map[string]int)
+	for t, count := range args {
+		if n, ok := fact.Args[t]; ok {
+			intersectedArgs[t] = min(count, n)
+		}
+	}
+	if totalParams(intersectedArgs) < m.minGroupSize {
+		return chainResult{}, false
+	}
+
+	stack := append(slices.Clone(callStack), fact.Stack...)
+	pkgs := make([]string, 0, len(stack))
+	for range callStack {
+		pkgs = append(pkgs, pass.Pkg.Path())
+	}
+	pkgs = append(pkgs, fact.Pkgs...)
+
+	return chainResult{
+		callStack: stack,
+		msg:       fmt.Sprintf("make struct with arguments: %s, for call stack: %s", formatArgs(intersectedArgs), strings.Join(stack, " -> ")),
+		leafFunc:  caller,
+		args:      intersectedArgs,
+		pkgs:      pkgs,
+	}, true
+}
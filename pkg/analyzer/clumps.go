@@ -0,0 +1,233 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"slices"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// categoryClump категория диагностики для повторяющихся групп параметров
+const categoryClump = "clump"
+
+// clumpResult описывает группу параметров (имя + тип), которая повторяется
+// в сигнатурах нескольких функций, не связанных цепочкой вызовов
+type clumpResult struct {
+	// params элементы группы в виде "имя тип" в порядке объявления в первой функции
+	params []string
+	// funcs функции, в сигнатурах которых встречается группа
+	funcs []*ast.FuncDecl
+}
+
+// reportClumps ищет повторяющиеся группы параметров среди функций пакета,
+// которые не вошли в найденные цепочки вызовов
+func (m *ParamAnalyzer) reportClumps(pass *analysis.Pass, chains []chainResult) {
+	inChains := NewSet[string]()
+	for _, chain := range chains {
+		for _, k := range chain.callStack {
+			inChains.Add(k)
+		}
+	}
+
+	var funcs []*ast.FuncDecl
+	m.ma.RLock()
+	for k, f := range m.all {
+		if inChains.Has(k) || !inPass(pass, f) || !m.filter.reportable(f) {
+			continue
+		}
+		funcs = append(funcs, f)
+	}
+	m.ma.RUnlock()
+
+	sort.Slice(funcs, func(i, j int) bool {
+		return funcs[i].Pos() < funcs[j].Pos()
+	})
+
+	for _, clump := range m.findClumps(m.withoutIgnored(funcs)) {
+		names := make([]string, 0, len(clump.funcs))
+		related := make([]analysis.RelatedInformation, 0, len(clump.funcs)-1)
+		for i, f := range clump.funcs {
+			k := m.funcDeclToKey(f)
+			names = append(names, k)
+			if i > 0 {
+				related = append(related, analysis.RelatedInformation{
+					Pos:     f.Pos(),
+					Message: "same arguments in " + k,
+				})
+			}
+		}
+
+		pass.Report(analysis.Diagnostic{
+			Pos:      clump.funcs[0].Pos(),
+			Category: categoryClump,
+			Message: fmt.Sprintf("make struct with arguments: %s, repeated in signatures: %s",
+				strings.Join(clump.params, ", "), strings.Join(names, ", ")),
+			Related: related,
+		})
+	}
+}
+
+// withoutIgnored исключает из поиска групп функции с директивами подавления.
+// Директива отмечается использованной, если функция вошла бы в группу
+func (m *ParamAnalyzer) withoutIgnored(funcs []*ast.FuncDecl) []*ast.FuncDecl {
+	kept := make([]*ast.FuncDecl, 0, len(funcs))
+	for _, f := range funcs {
+		if _, ok := m.ignoredFuncs[f]; !ok {
+			kept = append(kept, f)
+		}
+	}
+	if len(kept) == len(funcs) {
+		return funcs
+	}
+
+	for _, clump := range m.findClumps(funcs) {
+		for _, f := range clump.funcs {
+			if d, ok := m.ignoredFuncs[f]; ok {
+				d.used = true
+			}
+		}
+	}
+	return kept
+}
+
+// findClumps возвращает группы параметров размером не меньше minClumpSize,
+// которые встречаются не меньше чем в minClumpOccurrences сигнатурах
+func (m *ParamAnalyzer) findClumps(funcs []*ast.FuncDecl) []clumpResult {
+	if m.minClumpSize <= 0 || m.minClumpOccurrences <= 1 {
+		return nil
+	}
+
+	orders := make([][]string, len(funcs))
+	sets := make([]set[string], len(funcs))
+	for i, f := range funcs {
+		orders[i], sets[i] = m.paramSet(f)
+	}
+
+	// Кандидаты — пересечения любого числа сигнатур достаточного размера (замкнутые наборы,
+	// как в MineItemsets): к попарным пересечениям добавляются их пересечения с остальными
+	// сигнатурами. Иначе группа трех сигнатур, каждая пара которых делит еще один параметр,
+	// не нашлась бы
+	candidates := make(map[string]set[string])
+	var queue []set[string]
+	add := func(params set[string]) {
+		if len(params) < m.minClumpSize {
+			return
+		}
+		key := setKey(params)
+		if _, ok := candidates[key]; ok {
+			return
+		}
+		candidates[key] = params
+		queue = append(queue, params)
+	}
+	for i := range sets {
+		for j := i + 1; j < len(sets); j++ {
+			add(sets[i].Intersection(sets[j]))
+		}
+	}
+	for len(queue) > 0 {
+		params := queue[0]
+		queue = queue[1:]
+		for _, s := range sets {
+			add(params.Intersection(s))
+		}
+	}
+
+	type candidate struct {
+		params set[string]
+		funcs  []int
+	}
+
+	// Считаем, в скольких сигнатурах встречается каждый кандидат
+	found := make([]candidate, 0, len(candidates))
+	for _, params := range candidates {
+		var idx []int
+		for i, s := range sets {
+			if params.IsSubset(s) {
+				idx = append(idx, i)
+			}
+		}
+		if len(idx) >= m.minClumpOccurrences {
+			found = append(found, candidate{params: params, funcs: idx})
+		}
+	}
+
+	var result []clumpResult
+	for _, c := range found {
+		// Отбрасываем группу, если есть более широкая группа в тех же сигнатурах
+		if slices.ContainsFunc(found, func(o candidate) bool {
+			return len(o.params) > len(c.params) && len(o.funcs) == len(c.funcs) && c.params.IsSubset(o.params)
+		}) {
+			continue
+		}
+
+		params := make([]string, 0, len(c.params))
+		for _, p := range orders[c.funcs[0]] {
+			if c.params.Has(p) {
+				params = append(params, p)
+			}
+		}
+
+		clumpFuncs := make([]*ast.FuncDecl, 0, len(c.funcs))
+		for _, i := range c.funcs {
+			clumpFuncs = append(clumpFuncs, funcs[i])
+		}
+
+		result = append(result, clumpResult{params: params, funcs: clumpFuncs})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].funcs[0].Pos() != result[j].funcs[0].Pos() {
+			return result[i].funcs[0].Pos() < result[j].funcs[0].Pos()
+		}
+		return strings.Join(result[i].params, ",") < strings.Join(result[j].params, ",")
+	})
+
+	return result
+}
+
+// paramSet возвращает параметры функции в виде "имя тип" в порядке объявления и множеством
+func (m *ParamAnalyzer) paramSet(f *ast.FuncDecl) ([]string, set[string]) {
+	var order []string
+	for _, field := range f.Type.Params.List {
+		var typeStr string
+		if t := m.info.TypeOf(field.Type); t != nil {
+			typeStr = t.String()
+		} else {
+			typeStr = types.ExprString(field.Type) // fallback
+		}
+
+		for _, name := range field.Names {
+			if name == nil || name.Name == "_" {
+				continue
+			}
+			order = append(order, name.Name+" "+typeStr)
+		}
+	}
+
+	return order, NewSet(order...)
+}
+
+// setKey возвращает строковый ключ множества, не зависящий от порядка элементов
+func setKey(s set[string]) string {
+	items := make([]string, 0, len(s))
+	for v := range s {
+		items = append(items, v)
+	}
+	sort.Strings(items)
+	return strings.Join(items, "\x00")
+}
+
+// inPass проверяет, что узел находится в одном из файлов текущего пакета
+func inPass(pass *analysis.Pass, node ast.Node) bool {
+	for _, f := range pass.Files {
+		if f.FileStart <= node.Pos() && node.Pos() < f.FileEnd {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"testing"
+)
+
+func TestFindClumps(t *testing.T) {
+	code := `package test
+func a(x, y, z int, s string) {}
+func b(s string, x, y, z int) {}
+func c(x, y, z int) {}
+func d(x, y int, q string) {}`
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", code, 0)
+	if err != nil {
+		t.Fatalf("failed to parse code: %v", err)
+	}
+	var funcs []*ast.FuncDecl
+	for _, decl := range file.Decls {
+		funcs = append(funcs, decl.(*ast.FuncDecl))
+	}
+
+	tests := []struct {
+		name           string
+		minSize        int
+		minOccurrences int
+		expected       [][]string
+	}{
+		{
+			name:           "group in three signatures",
+			minSize:        3,
+			minOccurrences: 3,
+			expected:       [][]string{{"x int", "y int", "z int"}},
+		},
+		{
+			name:           "wider group in two signatures",
+			minSize:        3,
+			minOccurrences: 2,
+			expected:       [][]string{{"x int", "y int", "z int"}, {"x int", "y int", "z int", "s string"}},
+		},
+		{
+			name:           "too few occurrences",
+			minSize:        2,
+			minOccurrences: 5,
+			expected:       nil,
+		},
+		{
+			name:           "disabled",
+			minSize:        0,
+			minOccurrences: 0,
+			expected:       nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analyzer := NewMocker().Analyzer()
+			analyzer.minClumpSize = tt.minSize
+			analyzer.minClumpOccurrences = tt.minOccurrences
+
+			var got [][]string
+			for _, clump := range analyzer.findClumps(funcs) {
+				got = append(got, clump.params)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("findClumps() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package analyzer
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// wrappedName возвращает имя новой функции, которая принимает структуру вместо группы параметров
+func wrappedName(root string, typeName string) string {
+	return root + "With" + strings.TrimPrefix(typeName, root)
+}
+
+// compatWrapper строит правки, которые сохраняют старую сигнатуру экспортированной корневой
+// функции: корень переименовывается в newName, а под старым именем остается тонкая обертка
+// с пометкой Deprecated, которая собирает структуру и вызывает новую функцию.
+// Возвращает false, если обертку нельзя построить безопасно
+func (m *ParamAnalyzer) compatWrapper(pass *analysis.Pass, root *ast.FuncDecl, plan chainPlan, typeName, newName string, pointer bool) ([]analysis.TextEdit, bool) {
+	fn, ok := m.info.Defs[root.Name].(*types.Func)
+	if !ok || root.Type.TypeParams != nil {
+		return nil, false
+	}
+
+	// Новое имя не должно быть занято функцией пакета или методом получателя
+	sig := fn.Type().(*types.Signature)
+	callPrefix := ""
+	if recv := sig.Recv(); recv != nil {
+		if recv.Name() == "" || recv.Name() == "_" {
+			return nil, false
+		}
+		if named, ok := types.Unalias(derefType(recv.Type())).(*types.Named); !ok || named.TypeParams().Len() > 0 {
+			return nil, false
+		}
+		if obj, _, _ := types.LookupFieldOrMethod(recv.Type(), true, pass.Pkg, newName); obj != nil {
+			return nil, false
+		}
+		callPrefix = recv.Name() + "."
+	} else if pass.Pkg.Scope().Lookup(newName) != nil {
+		return nil, false
+	}
+
+	values := make([]string, 0, len(plan.fields))
+	for j, field := range plan.fields {
+		values = append(values, field.name+": "+plan.params[0][j].Name)
+	}
+	lit := fmt.Sprintf("%s%s{%s}", addrOf(pointer), typeName, strings.Join(values, ", "))
+
+	group := NewSet(plan.params[0]...)
+	var args []string
+	for _, field := range root.Type.Params.List {
+		if len(field.Names) == 0 {
+			return nil, false
+		}
+		_, variadic := field.Type.(*ast.Ellipsis)
+		for _, name := range field.Names {
+			switch {
+			case name.Name == "_":
+				return nil, false
+			case group.Has(name):
+				if variadic {
+					return nil, false
+				}
+				if lit != "" {
+					args = append(args, lit)
+					lit = ""
+				}
+			case variadic:
+				args = append(args, name.Name+"...")
+			default:
+				args = append(args, name.Name)
+			}
+		}
+	}
+
+	sigText, ok := signatureText(pass, root)
+	if !ok {
+		return nil, false
+	}
+
+	call := fmt.Sprintf("%s%s(%s)", callPrefix, newName, strings.Join(args, ", "))
+	if root.Type.Results != nil && len(root.Type.Results.List) > 0 {
+		call = "return " + call
+	}
+
+	var wrapper strings.Builder
+	wrapper.WriteString("\n\n")
+	if root.Doc != nil {
+		for _, c := range root.Doc.List {
+			wrapper.WriteString(c.Text + "\n")
+		}
+		wrapper.WriteString("//\n")
+	}
+	fmt.Fprintf(&wrapper, "// Deprecated: Use %s instead.\n", newName)
+	fmt.Fprintf(&wrapper, "%s {\n\t%s\n}", sigText, call)
+
+	edits := []analysis.TextEdit{
+		{Pos: root.Name.Pos(), End: root.Name.End(), NewText: []byte(newName)},
+		{Pos: root.End(), End: root.End(), NewText: []byte(wrapper.String())},
+	}
+	// Документация старой функции переходит к обертке
+	if root.Doc != nil {
+		edits = append(edits, analysis.TextEdit{
+			Pos:     root.Doc.Pos(),
+			End:     root.Doc.End(),
+			NewText: []byte(fmt.Sprintf("// %s is like %s but takes the grouped arguments as %s.", newName, root.Name.Name, typeName)),
+		})
+	}
+	return edits, true
+}
+
+// signatureText возвращает исходный текст сигнатуры функции вместе с комментариями параметров.
+// Если исходный код недоступен, сигнатура печатается заново без комментариев
+func signatureText(pass *analysis.Pass, f *ast.FuncDecl) (string, bool) {
+	tf := pass.Fset.File(f.Pos())
+	if src, ok := readSource(pass, tf); ok && f.Body != nil {
+		return strings.TrimSpace(string(src[tf.Offset(f.Pos()):tf.Offset(f.Body.Lbrace)])), true
+	}
+
+	var sb bytes.Buffer
+	if err := format.Node(&sb, pass.Fset, &ast.FuncDecl{Recv: f.Recv, Name: f.Name, Type: f.Type}); err != nil {
+		return "", false
+	}
+	return sb.String(), true
+}
+
+// renameCall заменяет имя вызываемой функции
+func renameCall(callExpr *ast.CallExpr, name string) (analysis.TextEdit, bool) {
+	var ident *ast.Ident
+	switch fun := ast.Unparen(callExpr.Fun).(type) {
+	case *ast.Ident:
+		ident = fun
+	case *ast.SelectorExpr:
+		ident = fun.Sel
+	default:
+		return analysis.TextEdit{}, false
+	}
+	return analysis.TextEdit{Pos: ident.Pos(), End: ident.End(), NewText: []byte(name)}, true
+}
+
+// derefType возвращает тип элемента для указателя
+func derefType(t types.Type) types.Type {
+	if ptr, ok := t.(*types.Pointer); ok {
+		return ptr.Elem()
+	}
+	return t
+}
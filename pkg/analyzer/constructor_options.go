@@ -0,0 +1,163 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// categoryConstructorOptions категория диагностики для конструкторов с необязательными параметрами
+const categoryConstructorOptions = "constructor-options"
+
+// ctorSuggestion описывает рекомендацию для конструктора с необязательными на вид параметрами
+type ctorSuggestion struct {
+	// optional имена необязательных на вид параметров
+	optional []string
+	// defaults количество аргументов-литералов нулевых значений среди необязательных параметров
+	defaults int
+	// total общее количество аргументов необязательных параметров во всех местах вызова
+	total int
+	// calls количество мест вызова
+	calls int
+	// functional рекомендует функциональные опции вместо структуры опций
+	functional bool
+}
+
+// reportConstructorOptions предлагает функциональные опции или структуру опций
+// для конструкторов пакета по результатам анализа мест их вызова
+func (m *ParamAnalyzer) reportConstructorOptions(pass *analysis.Pass) {
+	var ctors []*ast.FuncDecl
+	m.ma.RLock()
+	for f := range m.callSites {
+		if isConstructor(f) && inPass(pass, f) && m.filter.reportable(f) {
+			ctors = append(ctors, f)
+		}
+	}
+	m.ma.RUnlock()
+
+	sort.Slice(ctors, func(i, j int) bool {
+		return ctors[i].Pos() < ctors[j].Pos()
+	})
+
+	for _, f := range ctors {
+		m.ma.RLock()
+		calls := m.callSites[f]
+		m.ma.RUnlock()
+
+		s, ok := m.constructorOptions(f, calls)
+		if !ok {
+			continue
+		}
+
+		related := make([]analysis.RelatedInformation, 0, len(calls))
+		for _, callExpr := range calls {
+			related = append(related, analysis.RelatedInformation{
+				Pos:     callExpr.Pos(),
+				Message: "call site of " + f.Name.Name,
+			})
+		}
+
+		suggestion := "make options struct"
+		if s.functional {
+			suggestion = "use functional options"
+		}
+		pass.Report(analysis.Diagnostic{
+			Pos:      f.Pos(),
+			Category: categoryConstructorOptions,
+			Message: fmt.Sprintf("%s for optional arguments of %s: %s (literal defaults in %d of %d arguments at %d call sites)",
+				suggestion, f.Name.Name, strings.Join(s.optional, ", "), s.defaults, s.total, s.calls),
+			Related: related,
+		})
+	}
+}
+
+// constructorOptions определяет необязательные на вид параметры конструктора: логические
+// параметры и параметры, в которые в большинстве мест вызова передается литерал нулевого значения
+func (m *ParamAnalyzer) constructorOptions(f *ast.FuncDecl, calls []*ast.CallExpr) (ctorSuggestion, bool) {
+	if m.minOptionalParams <= 0 || len(calls) == 0 {
+		return ctorSuggestion{}, false
+	}
+
+	params := f.Type.Params.List
+	// Конструктор с вариативным параметром уже принимает опции
+	if len(params) > 0 {
+		if _, ok := params[len(params)-1].Type.(*ast.Ellipsis); ok {
+			return ctorSuggestion{}, false
+		}
+	}
+
+	var s ctorSuggestion
+	idx := 0
+	for _, field := range params {
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{ast.NewIdent("_")}
+		}
+
+		isBool := false
+		if t := m.info.TypeOf(field.Type); t != nil {
+			basic, ok := t.Underlying().(*types.Basic)
+			isBool = ok && basic.Info()&types.IsBoolean != 0
+		}
+
+		for _, name := range names {
+			zeros, sites := 0, 0
+			for _, callExpr := range calls {
+				if callExpr.Ellipsis.IsValid() || idx >= len(callExpr.Args) {
+					continue
+				}
+				sites++
+				if m.isZeroLiteral(callExpr.Args[idx]) {
+					zeros++
+				}
+			}
+			idx++
+
+			if !isBool && zeros*2 <= sites {
+				continue
+			}
+			s.optional = append(s.optional, name.Name)
+			s.defaults += zeros
+			s.total += sites
+		}
+	}
+
+	if len(s.optional) < m.minOptionalParams {
+		return ctorSuggestion{}, false
+	}
+
+	s.calls = len(calls)
+	// Если в большинстве вызовов передаются значения по умолчанию,
+	// вызывающему коду удобнее указывать только отличающиеся опции
+	s.functional = s.defaults*2 >= s.total
+	return s, true
+}
+
+// isZeroLiteral проверяет, что выражение является литералом нулевого значения: 0, "", false, nil
+func (m *ParamAnalyzer) isZeroLiteral(expr ast.Expr) bool {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.BasicLit:
+		v := constant.MakeFromLiteral(e.Value, e.Kind, 0)
+		switch v.Kind() {
+		case constant.String:
+			return constant.StringVal(v) == ""
+		case constant.Int, constant.Float, constant.Complex:
+			return constant.Sign(v) == 0
+		}
+	case *ast.Ident:
+		if e.Name != "false" && e.Name != "nil" {
+			return false
+		}
+		// Идентификатор может быть переопределен в пакете
+		if obj, ok := m.info.Uses[e]; ok && obj.Parent() != types.Universe {
+			return false
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+package analyzer
+
+import (
+	"go/parser"
+	"testing"
+)
+
+func TestIsZeroLiteral(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected bool
+	}{
+		{expr: "0", expected: true},
+		{expr: "0.0", expected: true},
+		{expr: "0x0", expected: true},
+		{expr: `""`, expected: true},
+		{expr: "``", expected: true},
+		{expr: "false", expected: true},
+		{expr: "nil", expected: true},
+		{expr: "(0)", expected: true},
+		{expr: "1", expected: false},
+		{expr: `"a"`, expected: false},
+		{expr: "true", expected: false},
+		{expr: "x", expected: false},
+		{expr: "f()", expected: false},
+	}
+
+	analyzer := NewMocker().Analyzer()
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			expr, err := parser.ParseExpr(tt.expr)
+			if err != nil {
+				t.Fatalf("failed to parse expression: %v", err)
+			}
+			if got := analyzer.isZeroLiteral(expr); got != tt.expected {
+				t.Errorf("isZeroLiteral(%s) = %v, want %v", tt.expr, got, tt.expected)
+			}
+		})
+	}
+}
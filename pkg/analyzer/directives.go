@@ -0,0 +1,157 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// categoryUnusedDirective категория диагностики для директив, которые ничего не подавляют
+const categoryUnusedDirective = "unused-directive"
+
+// directive описывает комментарий //usestruct:ignore или //nolint:usestruct, который
+// исключает функцию из цепочек или обрывает цепочку на вызове
+type directive struct {
+	// comment комментарий с директивой
+	comment *ast.Comment
+	// name директива без причины для сообщений
+	name string
+	// node объявление функции или вызов, к которому относится директива
+	node ast.Node
+	// used отмечает, что директива оборвала цепочку или подавила диагностику
+	used bool
+}
+
+// parseDirective разбирает комментарий с директивой подавления. Причина после директивы
+// необязательна: //usestruct:ignore причина или //nolint:usestruct // причина
+func parseDirective(text string) (string, bool) {
+	if isDirective(text, "//usestruct:ignore") {
+		return "//usestruct:ignore", true
+	}
+	if rest, ok := strings.CutPrefix(text, "//nolint:"); ok {
+		linters, _, _ := strings.Cut(rest, " ")
+		for _, linter := range strings.Split(linters, ",") {
+			if linter == "usestruct" {
+				return "//nolint:usestruct", true
+			}
+		}
+	}
+	return "", false
+}
+
+// isDirective проверяет, что комментарий является директивой name, за которой может идти причина
+func isDirective(text, name string) bool {
+	rest, ok := strings.CutPrefix(text, name)
+	return ok && (rest == "" || rest[0] == ' ' || rest[0] == '\t')
+}
+
+// collectDirectives находит директивы подавления в файлах пакета и связывает их с объявлениями
+// функций и вызовами. Директива в документации функции или на строке с func относится
+// к функции. Директива в конце строки относится к вызовам на этой строке, директива
+// на отдельной строке - к вызовам на следующей. Также отмечаются функции, в документации
+// которых есть директива //usestruct:fixed-signature
+func (m *ParamAnalyzer) collectDirectives(pass *analysis.Pass) {
+	for _, file := range pass.Files {
+		tf := pass.Fset.File(file.Pos())
+		if tf == nil {
+			continue
+		}
+		src, hasSrc := readSource(pass, tf)
+
+		byLine := make(map[int][]*directive)
+		for _, group := range file.Comments {
+			for _, c := range group.List {
+				name, ok := parseDirective(c.Text)
+				if !ok {
+					continue
+				}
+				d := &directive{comment: c, name: name}
+				m.directives = append(m.directives, d)
+
+				line := tf.Line(c.Slash)
+				if hasSrc && standalone(src, tf.Offset(c.Slash)) {
+					line++
+				}
+				byLine[line] = append(byLine[line], d)
+			}
+		}
+
+		ast.Inspect(file, func(node ast.Node) bool {
+			switch node := node.(type) {
+			case *ast.FuncDecl:
+				if node.Doc != nil && slices.ContainsFunc(node.Doc.List, func(c *ast.Comment) bool {
+					return isDirective(c.Text, "//usestruct:fixed-signature")
+				}) {
+					m.fixedFuncs.Add(node)
+				}
+				for _, d := range m.directives {
+					if d.node == nil && node.Doc != nil && node.Doc.Pos() <= d.comment.Pos() && d.comment.End() <= node.Doc.End() {
+						m.attachDirective(d, node)
+					}
+				}
+				for _, d := range byLine[tf.Line(node.Pos())] {
+					if d.node == nil {
+						m.attachDirective(d, node)
+					}
+				}
+			case *ast.CallExpr:
+				for _, d := range byLine[tf.Line(node.Pos())] {
+					if _, isFunc := d.node.(*ast.FuncDecl); !isFunc {
+						d.node = node
+						m.ignoredCalls[node] = d
+					}
+				}
+			}
+			return true
+		})
+	}
+}
+
+// attachDirective связывает директиву с объявлением функции
+func (m *ParamAnalyzer) attachDirective(d *directive, f *ast.FuncDecl) {
+	d.node = f
+	if _, ok := m.ignoredFuncs[f]; !ok {
+		m.ignoredFuncs[f] = d
+	}
+}
+
+// standalone проверяет, что перед комментарием на его строке нет кода
+func standalone(src []byte, off int) bool {
+	for i := off - 1; i >= 0 && src[i] != '\n'; i-- {
+		if src[i] != ' ' && src[i] != '\t' {
+			return false
+		}
+	}
+	return true
+}
+
+// suppressed проверяет, что позиция находится в функции или вызове с директивой подавления,
+// и отмечает директиву использованной
+func (m *ParamAnalyzer) suppressed(pos token.Pos) bool {
+	for _, d := range m.directives {
+		if d.node != nil && d.node.Pos() <= pos && pos < d.node.End() {
+			d.used = true
+			return true
+		}
+	}
+	return false
+}
+
+// reportUnusedDirectives сообщает о директивах подавления, которые не оборвали ни одной
+// цепочки и не подавили ни одной диагностики
+func (m *ParamAnalyzer) reportUnusedDirectives(pass *analysis.Pass) {
+	for _, d := range m.directives {
+		if d.used {
+			continue
+		}
+		pass.Report(analysis.Diagnostic{
+			Pos:      d.comment.Pos(),
+			End:      d.comment.End(),
+			Category: categoryUnusedDirective,
+			Message:  "unused directive " + d.name + ": it does not suppress any chain or diagnostic",
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package analyzer
+
+import "testing"
+
+func TestParseDirective(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+		ok       bool
+	}{
+		{text: "//usestruct:ignore", expected: "//usestruct:ignore", ok: true},
+		{text: "//usestruct:ignore mirrors the C API", expected: "//usestruct:ignore", ok: true},
+		{text: "//usestruct:ignored", ok: false},
+		{text: "// usestruct:ignore", ok: false},
+		{text: "//nolint:usestruct", expected: "//nolint:usestruct", ok: true},
+		{text: "//nolint:gocritic,usestruct // reason", expected: "//nolint:usestruct", ok: true},
+		{text: "//nolint:gocritic", ok: false},
+		{text: "//nolint:usestructs", ok: false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseDirective(tt.text)
+		if got != tt.expected || ok != tt.ok {
+			t.Errorf("parseDirective(%q) = %q, %v, want %q, %v", tt.text, got, ok, tt.expected, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package analyzer
+
+import (
+	"go/ast"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// GeneratedMode определяет обработку сгенерированных файлов
+// с заголовком "// Code generated ... DO NOT EDIT."
+type GeneratedMode int
+
+const (
+	// GeneratedIgnore исключает функции сгенерированных файлов из анализа
+	GeneratedIgnore GeneratedMode = iota
+	// GeneratedTraverse оставляет функции сгенерированных файлов звеньями цепочек,
+	// но не сообщает о них и не исправляет их
+	GeneratedTraverse
+)
+
+// Filter описывает пакеты, файлы и функции, которые исключаются из анализа.
+// Нулевое значение исключает только сгенерированные файлы
+type Filter struct {
+	// ExcludeFunctions регулярные выражения для полных имен функций
+	// вида path/to/pkg.Func или path/to/pkg.Type.Method
+	ExcludeFunctions []*regexp.Regexp
+	// ExcludePackages шаблоны путей пакетов в формате path.Match.
+	// Шаблон с суффиксом /... также совпадает со всеми вложенными пакетами
+	ExcludePackages []string
+	// ExcludeFiles шаблоны файлов в формате path.Match. Шаблон без / сравнивается
+	// с именем файла, шаблон с / - с окончанием пути файла
+	ExcludeFiles []string
+	// OnlyExported оставляет только диагностики экспортированных функций
+	OnlyExported bool
+	// SkipTests исключает тестовые файлы
+	SkipTests bool
+	// Generated определяет обработку сгенерированных файлов
+	Generated GeneratedMode
+}
+
+// ValidPattern проверяет синтаксис шаблона пакета или файла
+func ValidPattern(pattern string) bool {
+	_, err := path.Match(strings.TrimSuffix(pattern, "/..."), "")
+	return err == nil
+}
+
+// skipPackage проверяет, что пакет исключен целиком
+func (f Filter) skipPackage(pkgPath string) bool {
+	return slices.ContainsFunc(f.ExcludePackages, func(pattern string) bool {
+		return matchPackage(pattern, pkgPath)
+	})
+}
+
+// matchPackage сравнивает путь пакета с шаблоном в формате path.Match.
+// Шаблон с суффиксом /... также совпадает со всеми вложенными пакетами
+func matchPackage(pattern, pkgPath string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "/..."); ok {
+		if pkgPath == prefix || strings.HasPrefix(pkgPath, prefix+"/") {
+			return true
+		}
+		matched, _ := path.Match(prefix, pkgPath)
+		return matched
+	}
+	matched, _ := path.Match(pattern, pkgPath)
+	return matched
+}
+
+// skipFile проверяет, что файл исключен тестовым режимом или шаблоном
+func (f Filter) skipFile(name string) bool {
+	name = filepath.ToSlash(name)
+	if f.SkipTests && strings.HasSuffix(name, "_test.go") {
+		return true
+	}
+	for _, pattern := range f.ExcludeFiles {
+		if !strings.Contains(pattern, "/") {
+			if matched, _ := path.Match(pattern, path.Base(name)); matched {
+				return true
+			}
+			continue
+		}
+		// Шаблон с / сравнивается с окончаниями пути, начинающимися с элемента пути
+		parts := strings.Split(name, "/")
+		for i := range parts {
+			if matched, _ := path.Match(pattern, strings.Join(parts[i:], "/")); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// skipFunc проверяет, что функция исключена из анализа вместе с файлом, в котором объявлена.
+// key имя функции в пакете: Func или Type.Method
+func (f Filter) skipFunc(pass *analysis.Pass, decl *ast.FuncDecl, key string) bool {
+	if tf := pass.Fset.File(decl.Pos()); tf != nil && f.skipFile(tf.Name()) {
+		return true
+	}
+	name := pass.Pkg.Path() + "." + key
+	for _, re := range f.ExcludeFunctions {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// reportable проверяет, что о функции можно сообщать в режиме OnlyExported:
+// экспортированы и функция, и тип ее получателя
+func (f Filter) reportable(decl *ast.FuncDecl) bool {
+	if !f.OnlyExported {
+		return true
+	}
+	if !decl.Name.IsExported() {
+		return false
+	}
+	if decl.Recv == nil || len(decl.Recv.List) == 0 {
+		return true
+	}
+	typ := decl.Recv.List[0].Type
+	for {
+		switch t := typ.(type) {
+		case *ast.StarExpr:
+			typ = t.X
+		case *ast.IndexExpr:
+			typ = t.X
+		case *ast.IndexListExpr:
+			typ = t.X
+		case *ast.ParenExpr:
+			typ = t.X
+		case *ast.Ident:
+			return t.IsExported()
+		default:
+			return false
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package analyzer
+
+import "testing"
+
+func TestFilterSkipPackage(t *testing.T) {
+	f := Filter{ExcludePackages: []string{"example.com/mocks", "example.com/api/...", "example.com/*pb"}}
+
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{path: "example.com/mocks", expected: true},
+		{path: "example.com/mocks/db", expected: false},
+		{path: "example.com/api", expected: true},
+		{path: "example.com/api/v1", expected: true},
+		{path: "example.com/apiv1", expected: false},
+		{path: "example.com/userpb", expected: true},
+		{path: "example.com/service", expected: false},
+	}
+
+	for _, tt := range tests {
+		if got := f.skipPackage(tt.path); got != tt.expected {
+			t.Errorf("skipPackage(%q) = %v, want %v", tt.path, got, tt.expected)
+		}
+	}
+}
+
+func TestFilterSkipFile(t *testing.T) {
+	f := Filter{ExcludeFiles: []string{"*.pb.go", "mocks/*.go"}, SkipTests: true}
+
+	tests := []struct {
+		name     string
+		expected bool
+	}{
+		{name: "/src/api/user.pb.go", expected: true},
+		{name: "/src/api/user.go", expected: false},
+		{name: "/src/service/mocks/store.go", expected: true},
+		{name: "/src/service/mocks/db/store.go", expected: false},
+		{name: "/src/service/store_test.go", expected: true},
+	}
+
+	for _, tt := range tests {
+		if got := f.skipFile(tt.name); got != tt.expected {
+			t.Errorf("skipFile(%q) = %v, want %v", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func TestValidPattern(t *testing.T) {
+	for _, pattern := range []string{"example.com/api/...", "*_mock.go", "mocks/*.go"} {
+		if !ValidPattern(pattern) {
+			t.Errorf("ValidPattern(%q) = false, want true", pattern)
+		}
+	}
+	if ValidPattern("mocks/[.go") {
+		t.Error("ValidPattern(\"mocks/[.go\") = true, want false")
+	}
+}
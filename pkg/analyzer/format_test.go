@@ -1,29 +1,34 @@
 package analyzer
 
-// func TestFormatArgs(t *testing.T) {
-// 	args := map[string]int{
-// 		"int":     1,
-// 		"string":  1,
-// 		"float64": 1,
-// 	}
-// 	got := formatArgs(args)
+import (
+	"strings"
+	"testing"
+)
 
-// 	// Проверяем, что все элементы есть в строке, а их количество совпадает
-// 	parts := strings.Split(got, ", ")
-// 	expectedSet := map[string]int{
-// 		"int":     1,
-// 		"string":  1,
-// 		"float64": 1,
-// 	}
-// 	if len(parts) != 3 { // 3 элемента: int, string, float64
-// 		t.Errorf("formatArgs() = %v, want 3 elements", got)
-// 	}
-// 	for _, p := range parts {
-// 		if _, ok := expectedSet[p]; !ok {
-// 			t.Errorf("formatArgs() = %v, unexpected part: %v", got, p)
-// 		}
-// 	}
-// }
+func TestFormatArgs(t *testing.T) {
+	args := map[string]int{
+		"int":     1,
+		"string":  1,
+		"float64": 1,
+	}
+	got := formatArgs(args)
+
+	// Проверяем, что все элементы есть в строке, а их количество совпадает
+	parts := strings.Split(got, ", ")
+	expectedSet := map[string]int{
+		"int":     1,
+		"string":  1,
+		"float64": 1,
+	}
+	if len(parts) != 3 { // 3 элемента: int, string, float64
+		t.Errorf("formatArgs() = %v, want 3 elements", got)
+	}
+	for _, p := range parts {
+		if _, ok := expectedSet[p]; !ok {
+			t.Errorf("formatArgs() = %v, unexpected part: %v", got, p)
+		}
+	}
+}
 
 // func TestFormatStack(t *testing.T) {
 // 	fset := token.NewFileSet()
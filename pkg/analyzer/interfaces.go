@@ -0,0 +1,361 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// ifaceMethod описывает метод интерфейса пакета, который реализует метод цепочки
+type ifaceMethod struct {
+	// method объект метода интерфейса
+	method *types.Func
+	// decl объявление метода в интерфейсе
+	decl *ast.FuncType
+}
+
+// implementedInterfaces возвращает методы интерфейсов пакета, которые реализует метод f.
+// Возвращает false, если объявление метода интерфейса не найдено в пакете
+// (например, интерфейс встраивает интерфейс другого пакета) или интерфейс обобщенный
+func (m *ParamAnalyzer) implementedInterfaces(pass *analysis.Pass, f *ast.FuncDecl) ([]ifaceMethod, bool) {
+	if f.Recv == nil {
+		return nil, true
+	}
+	fn, ok := m.info.Defs[f.Name].(*types.Func)
+	if !ok {
+		return nil, false
+	}
+	recv := fn.Type().(*types.Signature).Recv().Type()
+
+	var result []ifaceMethod
+	seen := NewSet[*types.Func]()
+	scope := pass.Pkg.Scope()
+	for _, name := range scope.Names() {
+		tn, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok {
+			continue
+		}
+		iface, ok := tn.Type().Underlying().(*types.Interface)
+		// Метод с получателем-значением входит и в набор методов указателя на тип
+		if !ok || !types.Implements(recv, iface) && !types.Implements(types.NewPointer(recv), iface) {
+			continue
+		}
+
+		obj, _, _ := types.LookupFieldOrMethod(iface, false, pass.Pkg, f.Name.Name)
+		method, ok := obj.(*types.Func)
+		if !ok || seen.Has(method) {
+			continue
+		}
+		if named, ok := tn.Type().(*types.Named); ok && named.TypeParams().Len() > 0 {
+			return nil, false
+		}
+
+		decl, ok := findInterfaceMethod(pass, method)
+		if !ok {
+			return nil, false
+		}
+		seen.Add(method)
+		result = append(result, ifaceMethod{method: method, decl: decl})
+	}
+	return result, true
+}
+
+// localMethod сообщает, что экспортированный метод f виден только внутри пакета:
+// его тип не экспортирован, а сам метод реализует только неэкспортированные интерфейсы пакета
+func (m *ParamAnalyzer) localMethod(pass *analysis.Pass, f *ast.FuncDecl) bool {
+	fn, ok := m.info.Defs[f.Name].(*types.Func)
+	if !ok || f.Recv == nil {
+		return false
+	}
+	recv := fn.Type().(*types.Signature).Recv().Type()
+	if ptr, ok := recv.(*types.Pointer); ok {
+		recv = ptr.Elem()
+	}
+	named, ok := recv.(*types.Named)
+	if !ok || named.Obj().Exported() {
+		return false
+	}
+
+	ims, ok := m.implementedInterfaces(pass, f)
+	if !ok || len(ims) == 0 {
+		return false
+	}
+	for _, im := range ims {
+		iface, ok := im.method.Type().(*types.Signature).Recv().Type().(*types.Named)
+		if !ok || iface.Obj().Exported() {
+			return false
+		}
+	}
+	return true
+}
+
+// findInterfaceMethod ищет объявление метода интерфейса в файлах пакета
+func findInterfaceMethod(pass *analysis.Pass, method *types.Func) (*ast.FuncType, bool) {
+	var decl *ast.FuncType
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			it, ok := node.(*ast.InterfaceType)
+			if !ok || decl != nil {
+				return decl == nil
+			}
+			for _, field := range it.Methods.List {
+				ft, ok := field.Type.(*ast.FuncType)
+				if ok && len(field.Names) == 1 && pass.TypesInfo.Defs[field.Names[0]] == method {
+					decl = ft
+				}
+			}
+			return true
+		})
+	}
+	return decl, decl != nil
+}
+
+// implementations возвращает объявления методов типов пакета, реализующих метод интерфейса.
+// Возвращает false, если реализация объявлена вне пакета
+func (m *ParamAnalyzer) implementations(pass *analysis.Pass, im ifaceMethod) ([]*ast.FuncDecl, bool) {
+	iface, ok := im.method.Type().(*types.Signature).Recv().Type().Underlying().(*types.Interface)
+	if !ok {
+		return nil, false
+	}
+
+	var result []*ast.FuncDecl
+	seen := NewSet[*types.Func]()
+	scope := pass.Pkg.Scope()
+	for _, name := range scope.Names() {
+		tn, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || types.IsInterface(tn.Type()) {
+			continue
+		}
+		t := tn.Type()
+		if !types.Implements(t, iface) {
+			t = types.NewPointer(t)
+			if !types.Implements(t, iface) {
+				continue
+			}
+		}
+
+		obj, _, _ := types.LookupFieldOrMethod(t, false, pass.Pkg, im.method.Name())
+		fn, ok := obj.(*types.Func)
+		if !ok || seen.Has(fn) {
+			continue
+		}
+		seen.Add(fn)
+
+		decl, ok := m.funcDeclOf(pass, fn)
+		if !ok {
+			return nil, false
+		}
+		result = append(result, decl)
+	}
+	return result, true
+}
+
+// funcDeclOf ищет объявление функции или метода в файлах пакета
+func (m *ParamAnalyzer) funcDeclOf(pass *analysis.Pass, fn *types.Func) (*ast.FuncDecl, bool) {
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			if f, ok := decl.(*ast.FuncDecl); ok && m.info.Defs[f.Name] == fn {
+				return f, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// methodCalls возвращает вызовы методов в файлах пакета.
+// Возвращает false, если метод используется не только в вызовах (например, как значение)
+func (m *ParamAnalyzer) methodCalls(pass *analysis.Pass, methods set[*types.Func]) ([]*ast.CallExpr, bool) {
+	calls := NewSet[*ast.Ident]()
+	var result []*ast.CallExpr
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			callExpr, ok := node.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			if sel, ok := ast.Unparen(callExpr.Fun).(*ast.SelectorExpr); ok {
+				if fn, ok := m.info.Uses[sel.Sel].(*types.Func); ok && methods.Has(fn) {
+					calls.Add(sel.Sel)
+					result = append(result, callExpr)
+				}
+			}
+			return true
+		})
+	}
+
+	ok := true
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			ident, isIdent := node.(*ast.Ident)
+			if isIdent && !calls.Has(ident) {
+				if fn, isFunc := m.info.Uses[ident].(*types.Func); isFunc && methods.Has(fn) {
+					ok = false
+				}
+			}
+			return ok
+		})
+	}
+	return result, ok
+}
+
+// structLiteral возвращает составной литерал структуры из аргументов вызова:
+// positions содержит позицию аргумента для каждого поля. Элементы литерала следуют
+// в порядке аргументов, чтобы порядок их вычисления не изменился
+func structLiteral(pass *analysis.Pass, callExpr *ast.CallExpr, positions []int, fields []structField, typeName string, pointer bool) (string, bool) {
+	if callExpr.Ellipsis.IsValid() {
+		return "", false
+	}
+	values := make([]string, 0, len(fields))
+	for _, j := range argOrder(positions) {
+		if positions[j] >= len(callExpr.Args) {
+			return "", false
+		}
+		values = append(values, fields[j].name+": "+exprText(pass.Fset, callExpr.Args[positions[j]]))
+	}
+	return fmt.Sprintf("%s%s{%s}", addrOf(pointer), typeName, strings.Join(values, ", ")), true
+}
+
+// fieldPositions возвращает позиции параметров функции для каждого поля структуры
+func fieldPositions(f *ast.FuncDecl, params []*ast.Ident) []int {
+	index := paramIndex(f)
+	positions := make([]int, 0, len(params))
+	for _, p := range params {
+		positions = append(positions, index[p])
+	}
+	return positions
+}
+
+// positionParams возвращает именованные параметры функции на указанных позициях.
+// Для безымянных параметров и "_" возвращается nil
+func positionParams(f *ast.FuncDecl, positions []int) []*ast.Ident {
+	byIndex := make(map[int]*ast.Ident)
+	for ident, idx := range paramIndex(f) {
+		if ident.Name != "_" {
+			byIndex[idx] = ident
+		}
+	}
+	params := make([]*ast.Ident, 0, len(positions))
+	for _, idx := range positions {
+		params = append(params, byIndex[idx])
+	}
+	return params
+}
+
+// interfaceEdits переписывает под структуру параметров методы интерфейсов пакета,
+// которые реализует метод цепочки f, все остальные реализации этих методов
+// и их вызовы в пакете. chain содержит функции цепочки, реализации из которой
+// исправляются отдельно. Возвращает false, если исправление нельзя применить безопасно
+func (m *ParamAnalyzer) interfaceEdits(pass *analysis.Pass, f *ast.FuncDecl, params []*ast.Ident, chain set[*ast.FuncDecl], fields []structField, typeName string, pointer bool) ([]analysis.TextEdit, bool) {
+	ims, ok := m.implementedInterfaces(pass, f)
+	if !ok {
+		return nil, false
+	}
+	if len(ims) == 0 {
+		return nil, true
+	}
+
+	positions := fieldPositions(f, params)
+	drop := NewSet(positions...)
+	typ := pointerTo(pointer) + typeName
+
+	var edits []analysis.TextEdit
+	methods := NewSet[*types.Func]()
+	impls := NewSet[*ast.FuncDecl]()
+	for _, im := range ims {
+		methods.Add(im.method)
+		edits = append(edits, analysis.TextEdit{
+			Pos:     im.decl.Params.Opening,
+			End:     im.decl.Params.Closing + 1,
+			NewText: []byte(formatParams(pass, im.decl.Params, drop, listFreeName(im.decl.Params), typ)),
+		})
+
+		decls, ok := m.implementations(pass, im)
+		if !ok {
+			return nil, false
+		}
+		for _, decl := range decls {
+			if chain.Has(decl) || impls.Has(decl) {
+				continue
+			}
+			impls.Add(decl)
+			if fn, ok := m.info.Defs[decl.Name].(*types.Func); ok {
+				methods.Add(fn)
+			}
+
+			implEdits, ok := m.implementationEdits(pass, decl, positions, fields, typ)
+			if !ok {
+				return nil, false
+			}
+			edits = append(edits, implEdits...)
+		}
+	}
+
+	// Вызовы через интерфейс и вызовы других реализаций создают структуру из аргументов
+	calls, ok := m.methodCalls(pass, methods)
+	if !ok {
+		return nil, false
+	}
+	for _, callExpr := range calls {
+		for decl := range chain {
+			if decl.Pos() <= callExpr.Pos() && callExpr.End() <= decl.End() {
+				return nil, false
+			}
+		}
+		lit, ok := structLiteral(pass, callExpr, positions, fields, typeName, pointer)
+		if !ok {
+			return nil, false
+		}
+		edits = append(edits, replaceArgs(callExpr, drop, lit)...)
+	}
+	return edits, true
+}
+
+// implementationEdits переписывает другую реализацию метода интерфейса:
+// параметры группы заменяются структурой, их использования в теле - полями структуры
+func (m *ParamAnalyzer) implementationEdits(pass *analysis.Pass, decl *ast.FuncDecl, positions []int, fields []structField, typ string) ([]analysis.TextEdit, bool) {
+	name, ok := freeName([]*ast.FuncDecl{decl}, paramNames)
+	if !ok {
+		return nil, false
+	}
+
+	var params []*ast.Ident
+	var repl []string
+	for j, p := range positionParams(decl, positions) {
+		if p != nil {
+			params = append(params, p)
+			repl = append(repl, name+"."+fields[j].name)
+		}
+	}
+	if decl.Body != nil && strings.HasPrefix(typ, "*") && m.anyAssigned(decl.Body, params) {
+		return nil, false
+	}
+
+	edits := []analysis.TextEdit{{
+		Pos:     decl.Type.Params.Opening,
+		End:     decl.Type.Params.Closing + 1,
+		NewText: []byte(formatParams(pass, decl.Type.Params, NewSet(positions...), name, typ)),
+	}}
+	if decl.Body != nil {
+		edits = append(edits, m.replaceUses(decl.Body, params, repl, nil)...)
+	}
+	return edits, true
+}
+
+// listFreeName возвращает имя параметра-структуры, не занятое в списке параметров
+func listFreeName(list *ast.FieldList) string {
+	used := NewSet[string]()
+	for _, field := range list.List {
+		for _, name := range field.Names {
+			used.Add(name.Name)
+		}
+	}
+	for _, name := range paramNames {
+		if !used.Has(name) {
+			return name
+		}
+	}
+	return "_"
+}
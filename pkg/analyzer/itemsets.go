@@ -0,0 +1,221 @@
+package analyzer
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Signature описывает параметры одной функции для поиска частых наборов
+type Signature struct {
+	// Name полное имя функции
+	Name string
+	// Items элементы сигнатуры (типы, имена или пары "имя тип" параметров)
+	Items []string
+}
+
+// Itemset описывает набор элементов, который встречается в нескольких сигнатурах
+type Itemset struct {
+	// Items отсортированные элементы набора
+	Items []string
+	// Support количество сигнатур, содержащих набор
+	Support int
+	// Funcs имена функций, содержащих набор, в порядке их передачи
+	Funcs []string
+}
+
+// ItemsetOptions описывает пороги поиска частых наборов
+type ItemsetOptions struct {
+	// MinSupport минимальное количество сигнатур, содержащих набор
+	MinSupport int
+	// MinSize минимальный размер набора в результате
+	MinSize int
+	// MaxSize максимальный размер набора (0 — без ограничения)
+	MaxSize int
+	// Closed оставляет только замкнутые наборы: без надмножеств с той же поддержкой
+	Closed bool
+}
+
+// frequentSet промежуточный частый набор с множеством содержащих его сигнатур
+type frequentSet struct {
+	items []string
+	tids  set[int]
+}
+
+// MineItemsets ищет наборы элементов, которые встречаются не меньше чем в MinSupport сигнатурах.
+// Поиск идет по уровням, как в Apriori: наборы размера k+1 строятся из частых наборов
+// размера k с общим префиксом, поддержка считается пересечением множеств сигнатур.
+// Результат отсортирован по убыванию поддержки и размера набора.
+func MineItemsets(sigs []Signature, opts ItemsetOptions) []Itemset {
+	if opts.MinSupport <= 0 {
+		opts.MinSupport = 1
+	}
+
+	// Частые наборы из одного элемента
+	tidsByItem := make(map[string]set[int])
+	for i, sig := range sigs {
+		for _, item := range sig.Items {
+			if _, ok := tidsByItem[item]; !ok {
+				tidsByItem[item] = NewSet[int]()
+			}
+			tidsByItem[item].Add(i)
+		}
+	}
+
+	var level []frequentSet
+	for item, tids := range tidsByItem {
+		if len(tids) >= opts.MinSupport {
+			level = append(level, frequentSet{items: []string{item}, tids: tids})
+		}
+	}
+
+	var all []frequentSet
+	for size := 1; len(level) > 0; size++ {
+		sortFrequentSets(level)
+		all = append(all, level...)
+		if opts.MaxSize > 0 && size >= opts.MaxSize {
+			break
+		}
+		level = nextLevel(level, opts.MinSupport)
+	}
+
+	if opts.Closed {
+		all = closedSets(all)
+	}
+
+	result := make([]Itemset, 0, len(all))
+	for _, fs := range all {
+		if len(fs.items) < opts.MinSize {
+			continue
+		}
+
+		tids := make([]int, 0, len(fs.tids))
+		for i := range fs.tids {
+			tids = append(tids, i)
+		}
+		sort.Ints(tids)
+
+		funcs := make([]string, 0, len(tids))
+		for _, i := range tids {
+			funcs = append(funcs, sigs[i].Name)
+		}
+
+		result = append(result, Itemset{
+			Items:   fs.items,
+			Support: len(fs.tids),
+			Funcs:   funcs,
+		})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Support != result[j].Support {
+			return result[i].Support > result[j].Support
+		}
+		if len(result[i].Items) != len(result[j].Items) {
+			return len(result[i].Items) > len(result[j].Items)
+		}
+		return strings.Join(result[i].Items, "\x00") < strings.Join(result[j].Items, "\x00")
+	})
+
+	return result
+}
+
+// nextLevel строит частые наборы следующего размера из отсортированных наборов текущего уровня
+func nextLevel(level []frequentSet, minSupport int) []frequentSet {
+	known := NewSet[string]()
+	for _, fs := range level {
+		known.Add(strings.Join(fs.items, "\x00"))
+	}
+
+	var next []frequentSet
+	for i := range level {
+		for j := i + 1; j < len(level); j++ {
+			a, b := level[i].items, level[j].items
+			// Объединяем только наборы с общим префиксом длины k-1
+			if !slices.Equal(a[:len(a)-1], b[:len(b)-1]) {
+				break
+			}
+
+			items := append(slices.Clone(a), b[len(b)-1])
+			if !allSubsetsKnown(items, known) {
+				continue
+			}
+
+			tids := level[i].tids.Intersection(level[j].tids)
+			if len(tids) < minSupport {
+				continue
+			}
+			next = append(next, frequentSet{items: items, tids: tids})
+		}
+	}
+
+	return next
+}
+
+// allSubsetsKnown проверяет, что все подмножества набора на один элемент меньше являются частыми
+func allSubsetsKnown(items []string, known set[string]) bool {
+	if len(items) <= 2 {
+		return true
+	}
+
+	for skip := range items {
+		sub := make([]string, 0, len(items)-1)
+		sub = append(sub, items[:skip]...)
+		sub = append(sub, items[skip+1:]...)
+		if !known.Has(strings.Join(sub, "\x00")) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// closedSets оставляет только наборы, у которых нет надмножества с той же поддержкой.
+// Если такое надмножество есть, то есть и надмножество на один элемент больше, которое
+// содержится в тех же сигнатурах, поэтому набор сравнивается только с такими наборами
+func closedSets(all []frequentSet) []frequentSet {
+	// Множества элементов строятся один раз и группируются по размеру и сигнатурам
+	sets := make([]set[string], len(all))
+	keys := make([]string, len(all))
+	bySize := make(map[int]map[string][]set[string])
+	for i, fs := range all {
+		sets[i] = NewSet(fs.items...)
+		keys[i] = tidsKey(fs.tids)
+		if bySize[len(fs.items)] == nil {
+			bySize[len(fs.items)] = make(map[string][]set[string])
+		}
+		bySize[len(fs.items)][keys[i]] = append(bySize[len(fs.items)][keys[i]], sets[i])
+	}
+
+	var result []frequentSet
+	for i, fs := range all {
+		if slices.ContainsFunc(bySize[len(fs.items)+1][keys[i]], sets[i].IsSubset) {
+			continue
+		}
+		result = append(result, fs)
+	}
+	return result
+}
+
+// tidsKey возвращает ключ множества сигнатур
+func tidsKey(tids set[int]) string {
+	ids := make([]int, 0, len(tids))
+	for i := range tids {
+		ids = append(ids, i)
+	}
+	sort.Ints(ids)
+
+	var b strings.Builder
+	for _, i := range ids {
+		b.WriteString(strconv.Itoa(i))
+		b.WriteByte(',')
+	}
+	return b.String()
+}
+
+func sortFrequentSets(level []frequentSet) {
+	sort.Slice(level, func(i, j int) bool {
+		return slices.Compare(level[i].items, level[j].items) < 0
+	})
+}
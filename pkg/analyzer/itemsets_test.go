@@ -0,0 +1,66 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMineItemsets(t *testing.T) {
+	sigs := []Signature{
+		{Name: "Create", Items: []string{"ctx", "tenant", "user", "region"}},
+		{Name: "Delete", Items: []string{"ctx", "tenant", "user", "region", "force"}},
+		{Name: "Update", Items: []string{"ctx", "tenant", "user", "data"}},
+		{Name: "List", Items: []string{"ctx", "limit"}},
+	}
+
+	t.Run("closed itemsets ranked by support and size", func(t *testing.T) {
+		got := MineItemsets(sigs, ItemsetOptions{MinSupport: 2, MinSize: 1, Closed: true})
+		expected := []Itemset{
+			{Items: []string{"ctx"}, Support: 4, Funcs: []string{"Create", "Delete", "Update", "List"}},
+			{Items: []string{"ctx", "tenant", "user"}, Support: 3, Funcs: []string{"Create", "Delete", "Update"}},
+			{Items: []string{"ctx", "region", "tenant", "user"}, Support: 2, Funcs: []string{"Create", "Delete"}},
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("MineItemsets() = %v, want %v", got, expected)
+		}
+	})
+
+	t.Run("all itemsets include non-closed subsets", func(t *testing.T) {
+		got := MineItemsets(sigs, ItemsetOptions{MinSupport: 3, MinSize: 2})
+		// {ctx tenant} {ctx user} {tenant user} {ctx tenant user}
+		if len(got) != 4 {
+			t.Fatalf("expected 4 itemsets, got %d: %v", len(got), got)
+		}
+		if !reflect.DeepEqual(got[0].Items, []string{"ctx", "tenant", "user"}) {
+			t.Errorf("expected the largest itemset first, got %v", got[0].Items)
+		}
+	})
+
+	t.Run("closed itemsets skip subsets of wider sets with the same support", func(t *testing.T) {
+		wide := []Signature{
+			{Name: "A", Items: []string{"a", "b", "c", "d"}},
+			{Name: "B", Items: []string{"a", "b", "c", "d"}},
+		}
+		got := MineItemsets(wide, ItemsetOptions{MinSupport: 2, Closed: true})
+		expected := []Itemset{{Items: []string{"a", "b", "c", "d"}, Support: 2, Funcs: []string{"A", "B"}}}
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("MineItemsets() = %v, want %v", got, expected)
+		}
+	})
+
+	t.Run("max size limits the search", func(t *testing.T) {
+		got := MineItemsets(sigs, ItemsetOptions{MinSupport: 2, MaxSize: 2})
+		for _, s := range got {
+			if len(s.Items) > 2 {
+				t.Errorf("unexpected itemset larger than max size: %v", s.Items)
+			}
+		}
+	})
+
+	t.Run("support above all signatures returns nothing", func(t *testing.T) {
+		got := MineItemsets(sigs, ItemsetOptions{MinSupport: 5})
+		if len(got) != 0 {
+			t.Errorf("expected no itemsets, got %v", got)
+		}
+	})
+}
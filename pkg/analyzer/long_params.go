@@ -0,0 +1,151 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"strings"
+	"unicode"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// categoryLongParams категория диагностики для функций со слишком длинным списком параметров
+const categoryLongParams = "long-params"
+
+// LongParamsOptions описывает ограничения на количество параметров одной функции.
+// Нулевое значение специального ограничения означает, что используется Max.
+type LongParamsOptions struct {
+	// Max максимальное количество параметров функции (0 — без ограничения)
+	Max int
+	// MaxExported максимальное количество параметров экспортируемой функции
+	MaxExported int
+	// MaxMethods максимальное количество параметров метода
+	MaxMethods int
+	// MaxConstructors максимальное количество параметров конструктора (New...)
+	MaxConstructors int
+	// Filter исключает пакеты, файлы и функции из проверки
+	Filter Filter
+	// ForDir, если задана, возвращает ограничения для пакета по каталогу его файлов
+	ForDir func(dir string) (LongParamsOptions, error)
+}
+
+// DefaultLongParamsOptions возвращает ограничения по умолчанию: проверка выключена
+// и включается заданием Max или специальных ограничений
+func DefaultLongParamsOptions() LongParamsOptions {
+	return LongParamsOptions{}
+}
+
+// LongParamsAnalyzer создает анализатор, который сообщает о функциях,
+// количество параметров которых превышает ограничение
+func LongParamsAnalyzer(opts LongParamsOptions) *analysis.Analyzer {
+	return &analysis.Analyzer{
+		Name:     "longParamsAnalyzer",
+		Doc:      "reports functions whose parameter count exceeds the configured limit",
+		Run:      opts.run,
+		Requires: []*analysis.Analyzer{inspect.Analyzer},
+	}
+}
+
+func (o LongParamsOptions) run(pass *analysis.Pass) (any, error) {
+	inspector, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok {
+		return nil, fmt.Errorf("failed to get inspector from pass")
+	}
+
+	if dir, ok := packageDir(pass); ok && o.ForDir != nil {
+		dirOpts, err := o.ForDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		o = dirOpts
+	}
+	if o.Filter.skipPackage(pass.Pkg.Path()) {
+		return nil, nil
+	}
+	// Имена функций для фильтра строятся так же, как ключи цепочек
+	keys := &ParamAnalyzer{info: pass.TypesInfo}
+
+	declFilter := []ast.Node{
+		(*ast.FuncDecl)(nil),
+	}
+
+	inspector.Preorder(declFilter, func(node ast.Node) {
+		funcDecl := node.(*ast.FuncDecl)
+		if !o.Filter.reportable(funcDecl) || o.Filter.skipFunc(pass, funcDecl, keys.funcDeclToKey(funcDecl)) {
+			return
+		}
+		// Сгенерированный код не исправляют вручную
+		if file := fileOf(pass, funcDecl.Pos()); file != nil && ast.IsGenerated(file) {
+			return
+		}
+		limit, kind := o.limitFor(funcDecl)
+		if limit <= 0 {
+			return
+		}
+
+		count := countParams(funcDecl.Type.Params)
+		if count <= limit {
+			return
+		}
+
+		pass.Report(analysis.Diagnostic{
+			Pos:      funcDecl.Pos(),
+			Category: categoryLongParams,
+			Message: fmt.Sprintf("make struct for arguments of %s: %d parameters exceed the limit of %d for %s",
+				funcDecl.Name.Name, count, limit, kind),
+		})
+	})
+
+	return nil, nil
+}
+
+// limitFor возвращает ограничение для функции и описание вида функции для сообщения
+func (o LongParamsOptions) limitFor(f *ast.FuncDecl) (int, string) {
+	switch {
+	case isConstructor(f) && o.MaxConstructors > 0:
+		return o.MaxConstructors, "constructors"
+	case f.Recv != nil && o.MaxMethods > 0:
+		return o.MaxMethods, "methods"
+	case ast.IsExported(f.Name.Name) && o.MaxExported > 0:
+		return o.MaxExported, "exported functions"
+	}
+	return o.Max, "functions"
+}
+
+// isConstructor проверяет, что функция является конструктором вида New или NewXxx
+func isConstructor(f *ast.FuncDecl) bool {
+	if f.Recv != nil {
+		return false
+	}
+
+	name := f.Name.Name
+	for _, prefix := range []string{"New", "new"} {
+		rest, ok := strings.CutPrefix(name, prefix)
+		if !ok {
+			continue
+		}
+		if rest == "" || unicode.IsUpper([]rune(rest)[0]) {
+			return true
+		}
+	}
+	return false
+}
+
+// countParams считает параметры функции с учетом сгруппированных и безымянных параметров
+func countParams(params *ast.FieldList) int {
+	if params == nil {
+		return 0
+	}
+
+	total := 0
+	for _, param := range params.List {
+		if len(param.Names) > 0 {
+			total += len(param.Names)
+		} else {
+			total++
+		}
+	}
+	return total
+}
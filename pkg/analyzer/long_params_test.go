@@ -0,0 +1,72 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func TestLongParamsLimitFor(t *testing.T) {
+	opts := LongParamsOptions{Max: 5, MaxMethods: 3, MaxConstructors: 7}
+
+	tests := []struct {
+		name          string
+		code          string
+		expectedLimit int
+		expectedKind  string
+	}{
+		{
+			name:          "plain function",
+			code:          "package test\nfunc foo() {}",
+			expectedLimit: 5,
+			expectedKind:  "functions",
+		},
+		{
+			name:          "exported function falls back to max",
+			code:          "package test\nfunc Foo() {}",
+			expectedLimit: 5,
+			expectedKind:  "functions",
+		},
+		{
+			name:          "method",
+			code:          "package test\ntype T struct{}\nfunc (t T) Foo() {}",
+			expectedLimit: 3,
+			expectedKind:  "methods",
+		},
+		{
+			name:          "constructor",
+			code:          "package test\nfunc NewFoo() {}",
+			expectedLimit: 7,
+			expectedKind:  "constructors",
+		},
+		{
+			name:          "bare New constructor",
+			code:          "package test\nfunc New() {}",
+			expectedLimit: 7,
+			expectedKind:  "constructors",
+		},
+		{
+			name:          "word starting with New is not a constructor",
+			code:          "package test\nfunc Newline() {}",
+			expectedLimit: 5,
+			expectedKind:  "functions",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, "", tt.code, 0)
+			if err != nil {
+				t.Fatalf("failed to parse code: %v", err)
+			}
+			funcDecl := file.Decls[len(file.Decls)-1].(*ast.FuncDecl)
+
+			limit, kind := opts.limitFor(funcDecl)
+			if limit != tt.expectedLimit || kind != tt.expectedKind {
+				t.Errorf("limitFor() = %d, %q, want %d, %q", limit, kind, tt.expectedLimit, tt.expectedKind)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strings"
+	"unicode"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// receiverNames возможные имена получателя для методов объекта-метода
+var receiverNames = []string{"p", "o", "args", "params"}
+
+// methodObjectFix строит исправление для цепочки свободных функций одного файла, которое
+// предлагается, если нельзя ввести структуру параметров:
+// группа параметров переносится в новый тип, функции цепочки (кроме корня) становятся его
+// методами, а вызовы внутри цепочки выполняются через получатель. Корневая функция сохраняет
+// сигнатуру и создает объект из своих параметров. Поля объекта упорядочиваются по размещению
+// layout, при передаче по указателю методы получают указатель на объект.
+// Исправление не строится, если его нельзя применить безопасно, и для обобщенных цепочек
+func (m *ParamAnalyzer) methodObjectFix(pass *analysis.Pass, res chainResult, layout structLayout) (analysis.SuggestedFix, bool) {
+	plan, ok := m.planChain(pass, res)
+	if !ok {
+		return analysis.SuggestedFix{}, false
+	}
+	if len(layout.order) != len(plan.fields) {
+		return analysis.SuggestedFix{}, false
+	}
+	plan.reorder(layout.order)
+	pointer := layout.pointer
+
+	root := plan.funcs[0]
+	file := pass.Fset.File(root.Pos())
+	for i, f := range plan.funcs {
+		if f.Recv != nil || f.Body == nil || pass.Fset.File(f.Pos()) != file {
+			return analysis.SuggestedFix{}, false
+		}
+		// Функции цепочки после корня станут методами и исчезнут из области видимости пакета
+		if i > 0 && (ast.IsExported(f.Name.Name) || !m.onlyReferencedFrom(pass, f, plan.calls[i-1])) {
+			return analysis.SuggestedFix{}, false
+		}
+		// Через указатель изменения параметров стали бы видны вызывающим функциям
+		if i > 0 && pointer && m.anyAssigned(f.Body, plan.params[i]) {
+			return analysis.SuggestedFix{}, false
+		}
+	}
+
+	// Литерал объекта создается в начале тела корневой функции
+	typeName, renamed := pickStructName(pass, root.Name.Name, false, []ast.Node{root.Body})
+	recv, ok := freeName(plan.funcs, receiverNames)
+	if !ok {
+		return analysis.SuggestedFix{}, false
+	}
+	// Поля объекта не могут совпадать с именами его методов
+	methods := NewSet[string]()
+	for _, f := range plan.funcs[1:] {
+		methods.Add(f.Name.Name)
+	}
+	renamed = append(renamed, renameFields(plan.fields, methods.Has)...)
+
+	repl := make([]string, 0, len(plan.fields))
+	values := make([]string, 0, len(plan.fields))
+	for j, field := range plan.fields {
+		repl = append(repl, recv+"."+field.name)
+		values = append(values, field.name+": "+plan.params[0][j].Name)
+	}
+
+	edits := []analysis.TextEdit{
+		{
+			Pos:     declStart(root),
+			End:     declStart(root),
+			NewText: []byte(structDecl(pass.Fset, typeName, plan.fields) + "\n\n"),
+		},
+		insertStmt(pass.Fset, root.Body, fmt.Sprintf("%s := %s%s{%s}", recv, addrOf(pointer), typeName, strings.Join(values, ", "))),
+	}
+
+	for i, f := range plan.funcs {
+		params := plan.params[i]
+
+		// Вызовы следующей функции цепочки становятся вызовами метода без аргументов группы
+		var deletes []analysis.TextEdit
+		if i < len(plan.funcs)-1 {
+			next := plan.funcs[i+1]
+			for _, callExpr := range plan.calls[i] {
+				if !m.forwards(callExpr, next, params, plan.params[i+1]) {
+					return analysis.SuggestedFix{}, false
+				}
+				deletes = append(deletes, replaceArgs(callExpr, argPositions(next, plan.params[i+1]), "")...)
+				edits = append(edits, analysis.TextEdit{
+					Pos:     callExpr.Fun.Pos(),
+					End:     callExpr.Fun.Pos(),
+					NewText: []byte(recv + "."),
+				})
+			}
+		}
+		edits = append(edits, deletes...)
+		edits = append(edits, m.replaceUses(f.Body, params, repl, deletes)...)
+
+		if i == 0 {
+			continue
+		}
+
+		edits = append(edits,
+			analysis.TextEdit{
+				Pos:     f.Name.Pos(),
+				End:     f.Name.Pos(),
+				NewText: []byte("(" + recv + " " + pointerTo(pointer) + typeName + ") "),
+			},
+			analysis.TextEdit{
+				Pos:     f.Type.Params.Opening,
+				End:     f.Type.Params.Closing + 1,
+				NewText: []byte(formatParams(pass, f.Type.Params, argPositions(f, params), "", "")),
+			},
+		)
+	}
+
+	// Типы параметров, удаленных из сигнатур, могли быть единственным использованием пакета в файле
+	imports, ok := importEdits(pass, edits, declStart(root), plan.fields)
+	if !ok {
+		return analysis.SuggestedFix{}, false
+	}
+	edits = append(edits, imports...)
+
+	return analysis.SuggestedFix{
+		Message:   "Introduce method object " + typeName + renamedString(renamed),
+		TextEdits: edits,
+	}, true
+}
+
+// onlyReferencedFrom проверяет, что функция используется в пакете только в переданных вызовах
+func (m *ParamAnalyzer) onlyReferencedFrom(pass *analysis.Pass, f *ast.FuncDecl, calls []*ast.CallExpr) bool {
+	obj := m.info.ObjectOf(f.Name)
+	if obj == nil {
+		return false
+	}
+
+	allowed := NewSet[ast.Node]()
+	for _, callExpr := range calls {
+		switch fun := ast.Unparen(callExpr.Fun).(type) {
+		case *ast.Ident:
+			allowed.Add(fun)
+		case *ast.SelectorExpr:
+			allowed.Add(fun.Sel)
+		}
+	}
+
+	ok := true
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			ident, isIdent := node.(*ast.Ident)
+			if isIdent && m.info.Uses[ident] == obj && !allowed.Has(ident) {
+				ok = false
+			}
+			return ok
+		})
+	}
+	return ok
+}
+
+// structName возвращает имя типа с суффиксом для группы параметров корневой функции
+func structName(root string, exported bool, suffix string) string {
+	r := []rune(root)
+	if exported {
+		r[0] = unicode.ToUpper(r[0])
+	} else {
+		r[0] = unicode.ToLower(r[0])
+	}
+	return string(r) + suffix
+}
+
+// structDecl возвращает отформатированное объявление структуры с переданными полями
+func structDecl(fset *token.FileSet, name string, fields []structField) string {
+	return gofmt("type " + name + " struct {\n" + fieldLines(fset, fields) + "}")
+}
+
+// declStart возвращает позицию начала объявления функции вместе с документацией
+func declStart(f *ast.FuncDecl) token.Pos {
+	if f.Doc != nil {
+		return f.Doc.Pos()
+	}
+	return f.Pos()
+}
+
+// freeName возвращает первое имя из кандидатов, которое не используется в функциях
+func freeName(funcs []*ast.FuncDecl, candidates []string) (string, bool) {
+	used := NewSet[string]()
+	for _, f := range funcs {
+		ast.Inspect(f, func(node ast.Node) bool {
+			if ident, ok := node.(*ast.Ident); ok {
+				used.Add(ident.Name)
+			}
+			return true
+		})
+	}
+
+	for _, name := range candidates {
+		if !used.Has(name) {
+			return name, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,94 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// structSuffixes суффиксы имени структуры для группы параметров в порядке предпочтения
+var structSuffixes = []string{"Params", "Args"}
+
+// pickName возвращает первое свободное имя из кандидатов, а если все они заняты -
+// первый кандидат с наименьшим свободным числовым суффиксом
+func pickName(candidates []string, taken func(string) bool) string {
+	for _, name := range candidates {
+		if !taken(name) {
+			return name
+		}
+	}
+	for i := 2; ; i++ {
+		if name := candidates[0] + strconv.Itoa(i); !taken(name) {
+			return name
+		}
+	}
+}
+
+// typeNameTaken проверяет, что имя нового типа уже объявлено в пакете, импортировано
+// в одном из его файлов, является предопределенным или перекрыто в местах, где на тип
+// будут ссылаться
+func typeNameTaken(pass *analysis.Pass, name string, uses []ast.Node) bool {
+	if pass.Pkg.Scope().Lookup(name) != nil || types.Universe.Lookup(name) != nil {
+		return true
+	}
+	for _, file := range pass.Files {
+		if scope := pass.TypesInfo.Scopes[file]; scope != nil && scope.Lookup(name) != nil {
+			return true
+		}
+	}
+	for _, node := range uses {
+		scope := pass.Pkg.Scope().Innermost(node.Pos())
+		if scope == nil {
+			continue
+		}
+		if _, obj := scope.LookupParent(name, node.Pos()); obj != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// pickStructName выбирает свободное имя структуры для группы параметров корневой функции.
+// Второе значение описывает замену, если предпочтительное имя занято
+func pickStructName(pass *analysis.Pass, root string, exported bool, uses []ast.Node) (string, []string) {
+	candidates := make([]string, 0, len(structSuffixes))
+	for _, suffix := range structSuffixes {
+		candidates = append(candidates, structName(root, exported, suffix))
+	}
+	name := pickName(candidates, func(name string) bool {
+		return typeNameTaken(pass, name, uses)
+	})
+	if name != candidates[0] {
+		return name, []string{candidates[0] + " -> " + name}
+	}
+	return name, nil
+}
+
+// renameFields заменяет занятые и повторяющиеся имена полей свободными.
+// Возвращает описания замен
+func renameFields(fields []structField, taken func(string) bool) []string {
+	used := NewSet[string]()
+	var renamed []string
+	for i := range fields {
+		name := pickName([]string{fields[i].name}, func(name string) bool {
+			return used.Has(name) || (taken != nil && taken(name))
+		})
+		if name != fields[i].name {
+			renamed = append(renamed, fields[i].name+" -> "+name)
+			fields[i].name = name
+		}
+		used.Add(name)
+	}
+	return renamed
+}
+
+// renamedString возвращает дополнение к сообщению исправления о замененных именах
+func renamedString(renamed []string) string {
+	if len(renamed) == 0 {
+		return ""
+	}
+	return " (renamed to avoid conflicts: " + strings.Join(renamed, ", ") + ")"
+}
@@ -0,0 +1,40 @@
+package analyzer
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPickName(t *testing.T) {
+	tests := []struct {
+		name       string
+		candidates []string
+		taken      []string
+		expected   string
+	}{
+		{name: "first free", candidates: []string{"ServeParams", "ServeArgs"}, expected: "ServeParams"},
+		{name: "next candidate", candidates: []string{"ServeParams", "ServeArgs"}, taken: []string{"ServeParams"}, expected: "ServeArgs"},
+		{name: "numbered", candidates: []string{"ServeParams", "ServeArgs"}, taken: []string{"ServeParams", "ServeArgs", "ServeParams2"}, expected: "ServeParams3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pickName(tt.candidates, func(name string) bool { return slices.Contains(tt.taken, name) }); got != tt.expected {
+				t.Errorf("pickName() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRenameFields(t *testing.T) {
+	fields := []structField{{name: "title"}, {name: "width"}, {name: "Title"}}
+	renamed := renameFields(fields, func(name string) bool { return name == "title" || name == "title2" })
+
+	names := []string{fields[0].name, fields[1].name, fields[2].name}
+	if !slices.Equal(names, []string{"title3", "width", "Title"}) {
+		t.Errorf("renameFields() names = %v", names)
+	}
+	if !slices.Equal(renamed, []string{"title -> title3"}) {
+		t.Errorf("renameFields() renamed = %v", renamed)
+	}
+}
@@ -0,0 +1,75 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Override переопределяет пороги анализа цепочек для пакетов. Нулевые значения
+// сохраняют настройки анализатора
+type Override struct {
+	// Packages шаблоны путей пакетов в формате path.Match.
+	// Шаблон с суффиксом /... также совпадает со всеми вложенными пакетами
+	Packages []string
+	// MinRequiredParams минимальное количество параметров функции для анализа
+	MinRequiredParams int
+	// MaxRecursionDepth максимальная глубина рекурсии при анализе цепочки вызовов
+	MaxRecursionDepth int
+	// MinGroupSize минимальное количество параметров, передаваемых через всю цепочку
+	MinGroupSize int
+	// MinChainLength минимальное количество функций в цепочке, о которой сообщается
+	MinChainLength int
+}
+
+// matches проверяет, что переопределение относится к пакету
+func (o Override) matches(pkgPath string) bool {
+	return slices.ContainsFunc(o.Packages, func(pattern string) bool {
+		return matchPackage(pattern, pkgPath)
+	})
+}
+
+// forPackage возвращает настройки для пакета. Подходящие переопределения применяются
+// по порядку, поэтому более позднее переопределение побеждает
+func (opts Options) forPackage(pkgPath string) Options {
+	for _, o := range opts.Overrides {
+		if !o.matches(pkgPath) {
+			continue
+		}
+		if o.MinRequiredParams > 0 {
+			opts.MinRequiredParams = o.MinRequiredParams
+		}
+		if o.MaxRecursionDepth > 0 {
+			opts.MaxRecursionDepth = o.MaxRecursionDepth
+		}
+		if o.MinGroupSize > 0 {
+			opts.MinGroupSize = o.MinGroupSize
+		}
+		if o.MinChainLength > 0 {
+			opts.MinChainLength = o.MinChainLength
+		}
+	}
+	return opts
+}
+
+// forPass возвращает настройки для пакета прохода: настройки каталога пакета (см. ForDir)
+// с примененными переопределениями
+func (opts Options) forPass(pass *analysis.Pass) (Options, error) {
+	if dir, ok := packageDir(pass); ok && opts.ForDir != nil {
+		dirOpts, err := opts.ForDir(dir)
+		if err != nil {
+			return Options{}, err
+		}
+		opts = dirOpts
+	}
+	return opts.forPackage(pass.Pkg.Path()), nil
+}
+
+// packageDir возвращает каталог файлов пакета
+func packageDir(pass *analysis.Pass) (string, bool) {
+	if len(pass.Files) == 0 {
+		return "", false
+	}
+	return filepath.Dir(pass.Fset.File(pass.Files[0].Pos()).Name()), true
+}
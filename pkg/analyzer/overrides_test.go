@@ -0,0 +1,31 @@
+package analyzer
+
+import "testing"
+
+func TestOptionsForPackage(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Overrides = []Override{
+		{Packages: []string{"example.com/app/..."}, MinGroupSize: 4, MaxRecursionDepth: 5},
+		{Packages: []string{"example.com/app/handlers", "example.com/app/*api"}, MinGroupSize: 5, MinChainLength: 3},
+	}
+
+	tests := []struct {
+		path     string
+		group    int
+		length   int
+		maxDepth int
+	}{
+		{path: "example.com/lib", group: 3, length: 2, maxDepth: 10},
+		{path: "example.com/app/domain", group: 4, length: 2, maxDepth: 5},
+		{path: "example.com/app/handlers", group: 5, length: 3, maxDepth: 5},
+		{path: "example.com/app/userapi", group: 5, length: 3, maxDepth: 5},
+	}
+
+	for _, tt := range tests {
+		got := opts.forPackage(tt.path)
+		if got.MinGroupSize != tt.group || got.MinChainLength != tt.length || got.MaxRecursionDepth != tt.maxDepth {
+			t.Errorf("forPackage(%q) = group %d, length %d, depth %d, want %d, %d, %d", tt.path,
+				got.MinGroupSize, got.MinChainLength, got.MaxRecursionDepth, tt.group, tt.length, tt.maxDepth)
+		}
+	}
+}
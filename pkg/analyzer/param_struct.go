@@ -0,0 +1,232 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// paramNames возможные имена параметра-структуры
+var paramNames = []string{"p", "params", "args", "opts"}
+
+// paramStructFix строит исправление, которое вводит структуру для группы параметров:
+// все функции цепочки принимают структуру вместо параметров группы, вызовы внутри цепочки
+// передают ее дальше, а вызовы корневой функции в пакете создают ее из своих аргументов.
+// Поля структуры упорядочиваются по размещению layout.
+// При передаче по указателю функции принимают указатель на структуру.
+// Сигнатура экспортированного корня меняется, только если включены обертки совместимости:
+// тогда он сохраняет старую сигнатуру.
+// Исправление не строится, если его нельзя применить безопасно, и для обобщенных цепочек
+func (m *ParamAnalyzer) paramStructFix(pass *analysis.Pass, res chainResult, layout structLayout) (analysis.SuggestedFix, bool) {
+	plan, ok := m.planChain(pass, res)
+	if !ok {
+		return analysis.SuggestedFix{}, false
+	}
+	if len(layout.order) != len(plan.fields) {
+		return analysis.SuggestedFix{}, false
+	}
+	plan.reorder(layout.order)
+	pointer := layout.pointer
+
+	root := plan.funcs[0]
+	for i, f := range plan.funcs {
+		if f.Body == nil {
+			return analysis.SuggestedFix{}, false
+		}
+		if i == 0 {
+			continue
+		}
+		// Сигнатуры функций после корня меняются, поэтому других вызовов быть не должно.
+		// Экспортированный метод допустим, если его вызывают только через интерфейсы пакета
+		if ast.IsExported(f.Name.Name) && !m.localMethod(pass, f) || !m.onlyReferencedFrom(pass, f, plan.calls[i-1]) {
+			return analysis.SuggestedFix{}, false
+		}
+		// Через указатель изменения параметров стали бы видны вызывающим функциям
+		if pointer && m.anyAssigned(f.Body, plan.params[i]) {
+			return analysis.SuggestedFix{}, false
+		}
+	}
+
+	exported := ast.IsExported(root.Name.Name)
+	// Экспортированный корень может сохранить старую сигнатуру в виде обертки.
+	// Без нее изменение сигнатуры сломало бы вызовы из других пакетов
+	wrapper := m.compatWrappers && exported
+	if exported && !wrapper && pass.Pkg.Name() != "main" {
+		return analysis.SuggestedFix{}, false
+	}
+
+	m.ma.RLock()
+	rootCalls := m.callSites[root]
+	m.ma.RUnlock()
+	if !wrapper && !m.onlyReferencedFrom(pass, root, rootCalls) {
+		return analysis.SuggestedFix{}, false
+	}
+	for _, callExpr := range rootCalls {
+		if callExpr.Ellipsis.IsValid() {
+			return analysis.SuggestedFix{}, false
+		}
+		for _, f := range plan.funcs {
+			if f.Pos() <= callExpr.Pos() && callExpr.End() <= f.End() {
+				return analysis.SuggestedFix{}, false
+			}
+		}
+	}
+
+	// Литералы структуры создаются в местах вызова корня
+	uses := make([]ast.Node, 0, len(rootCalls))
+	for _, callExpr := range rootCalls {
+		uses = append(uses, callExpr)
+	}
+	typeName, renamed := pickStructName(pass, root.Name.Name, exported, uses)
+	name, ok := freeName(plan.funcs, paramNames)
+	if !ok {
+		return analysis.SuggestedFix{}, false
+	}
+
+	// Поля структуры экспортированной функции должны быть доступны вызывающим из других пакетов
+	if exported {
+		fields := make([]structField, 0, len(plan.fields))
+		for _, field := range plan.fields {
+			field.name = exportName(field.name)
+			fields = append(fields, field)
+		}
+		plan.fields = fields
+	}
+	renamed = append(renamed, renameFields(plan.fields, nil)...)
+
+	repl := make([]string, 0, len(plan.fields))
+	for _, field := range plan.fields {
+		repl = append(repl, name+"."+field.name)
+	}
+
+	edits := []analysis.TextEdit{{
+		Pos:     declStart(root),
+		End:     declStart(root),
+		NewText: []byte(structDecl(pass.Fset, typeName, plan.fields) + "\n\n"),
+	}}
+
+	for i, f := range plan.funcs {
+		params := plan.params[i]
+
+		// Вызовы следующей функции цепочки передают структуру вместо аргументов группы
+		var argEdits []analysis.TextEdit
+		if i < len(plan.funcs)-1 {
+			next := plan.funcs[i+1]
+			for _, callExpr := range plan.calls[i] {
+				if !m.forwards(callExpr, next, params, plan.params[i+1]) {
+					return analysis.SuggestedFix{}, false
+				}
+				argEdits = append(argEdits, replaceArgs(callExpr, argPositions(next, plan.params[i+1]), name)...)
+			}
+		}
+		edits = append(edits, argEdits...)
+		edits = append(edits, m.replaceUses(f.Body, params, repl, argEdits)...)
+		edits = append(edits, analysis.TextEdit{
+			Pos:     f.Type.Params.Opening,
+			End:     f.Type.Params.Closing + 1,
+			NewText: []byte(formatParams(pass, f.Type.Params, argPositions(f, params), name, pointerTo(pointer)+typeName)),
+		})
+	}
+
+	// Методы цепочки могут реализовывать интерфейсы пакета: их объявления, остальные
+	// реализации и вызовы через интерфейс переписываются вместе с цепочкой.
+	// Обертка совместимости сохраняет старую сигнатуру корня, поэтому его интерфейсы не меняются
+	chain := NewSet(plan.funcs...)
+	for i, f := range plan.funcs {
+		if i == 0 && wrapper {
+			continue
+		}
+		ifaceEdits, ok := m.interfaceEdits(pass, f, plan.params[i], chain, plan.fields, typeName, pointer)
+		if !ok {
+			return analysis.SuggestedFix{}, false
+		}
+		edits = append(edits, ifaceEdits...)
+	}
+
+	message := "Introduce parameter struct " + typeName
+	newName := root.Name.Name
+	if wrapper {
+		newName = wrappedName(root.Name.Name, typeName)
+		wrapperEdits, ok := m.compatWrapper(pass, root, plan, typeName, newName, pointer)
+		if !ok {
+			return analysis.SuggestedFix{}, false
+		}
+		edits = append(edits, wrapperEdits...)
+		message += " and keep " + root.Name.Name + " as deprecated wrapper"
+	}
+
+	// Вызовы корневой функции в пакете создают структуру из своих аргументов
+	// и обращаются к новой функции в обход обертки
+	positions := fieldPositions(root, plan.params[0])
+	for _, callExpr := range rootCalls {
+		if wrapper {
+			rename, ok := renameCall(callExpr, newName)
+			if !ok {
+				return analysis.SuggestedFix{}, false
+			}
+			edits = append(edits, rename)
+		}
+		// Поля упорядочены по размещению, а элементы литерала — по аргументам вызова,
+		// чтобы порядок вычисления аргументов не изменился
+		values := make([]string, 0, len(plan.fields))
+		for _, j := range argOrder(positions) {
+			if positions[j] >= len(callExpr.Args) {
+				return analysis.SuggestedFix{}, false
+			}
+			values = append(values, plan.fields[j].name+": "+exprText(pass.Fset, callExpr.Args[positions[j]]))
+		}
+		lit := fmt.Sprintf("%s%s{%s}", addrOf(pointer), typeName, strings.Join(values, ", "))
+		edits = append(edits, replaceArgs(callExpr, argPositions(root, plan.params[0]), lit)...)
+	}
+
+	// Типы параметров, удаленных из сигнатур, могли быть единственным использованием пакета в файле
+	imports, ok := importEdits(pass, edits, declStart(root), plan.fields)
+	if !ok {
+		return analysis.SuggestedFix{}, false
+	}
+	edits = append(edits, imports...)
+
+	return analysis.SuggestedFix{
+		Message:   message + renamedString(renamed),
+		TextEdits: edits,
+	}, true
+}
+
+// argOrder возвращает индексы полей структуры в порядке позиций их аргументов в вызове
+func argOrder(positions []int) []int {
+	order := make([]int, len(positions))
+	for j := range order {
+		order[j] = j
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return positions[order[a]] < positions[order[b]]
+	})
+	return order
+}
+
+// exportName возвращает имя с заглавной первой буквой
+func exportName(name string) string {
+	r := []rune(name)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+// pointerTo возвращает префикс типа-указателя, если структура передается по указателю
+func pointerTo(pointer bool) string {
+	if pointer {
+		return "*"
+	}
+	return ""
+}
+
+// addrOf возвращает оператор взятия адреса, если структура передается по указателю
+func addrOf(pointer bool) string {
+	if pointer {
+		return "&"
+	}
+	return ""
+}
@@ -0,0 +1,209 @@
+package analyzer
+
+import (
+	"go/types"
+	"slices"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// structPackage выбирает пакет для структуры цепочки, которая проходит через несколько пакетов:
+// самый нижний в графе импортов пакет, который все функции цепочки могут импортировать
+// без циклов и который сам может импортировать пакеты типов полей. Кандидатами являются
+// пакеты цепочки и пакеты типов полей, которые относятся к модулю анализируемого пакета.
+// Возвращает false, если такого пакета нет
+func (m *ParamAnalyzer) structPackage(pass *analysis.Pass, res chainResult) (string, bool) {
+	var chain []string
+	for _, p := range res.pkgs {
+		if !slices.Contains(chain, p) {
+			chain = append(chain, p)
+		}
+	}
+	known := knownPackages(pass.Pkg)
+
+	var fieldPkgs []*types.Package
+	for _, field := range m.rootFields(res) {
+		if t := m.info.TypeOf(field.typ); t != nil {
+			fieldPkgs = typePackages(t, fieldPkgs)
+		}
+	}
+
+	valid := func(q string) bool {
+		qi := -1
+		for i, p := range chain {
+			if p == q {
+				qi = i
+			}
+		}
+		for i, p := range chain {
+			if p == q {
+				continue
+			}
+			if !canImport(p, q) {
+				return false
+			}
+			// Пакет цепочки импортирует пакеты, которые идут в цепочке после него,
+			// поэтому их импорт этого пакета дал бы цикл
+			if qi >= 0 && i > qi {
+				return false
+			}
+			if qi < 0 && imports(known[q], p) {
+				return false
+			}
+		}
+		for _, t := range fieldPkgs {
+			if t.Path() != q && (!canImport(q, t.Path()) || imports(t, q)) {
+				return false
+			}
+		}
+		return true
+	}
+
+	// Кандидаты от листа цепочки к корню, затем пакеты типов полей.
+	// Структуру можно разместить только в пакете модуля
+	var candidates []string
+	for i := len(chain) - 1; i >= 0; i-- {
+		if inModule(pass, chain[i]) {
+			candidates = append(candidates, chain[i])
+		}
+	}
+	var extra []string
+	for _, t := range fieldPkgs {
+		if !slices.Contains(chain, t.Path()) && !slices.Contains(extra, t.Path()) && inModule(pass, t.Path()) {
+			extra = append(extra, t.Path())
+		}
+	}
+	sort.Strings(extra)
+	candidates = append(candidates, extra...)
+
+	var found []string
+	for _, q := range candidates {
+		if valid(q) {
+			found = append(found, q)
+		}
+	}
+	// Самый нижний пакет не импортирует другие подходящие пакеты
+	for _, q := range found {
+		lowest := true
+		for _, other := range found {
+			if other != q && imports(known[q], other) {
+				lowest = false
+			}
+		}
+		if lowest {
+			return q, true
+		}
+	}
+	return "", false
+}
+
+// placement возвращает рекомендацию по размещению структуры для сообщения.
+// Возвращает false, если подходящего пакета нет, а цепочка выходит за пределы модуля:
+// функции другого модуля изменить нельзя, поэтому рекомендовать нечего
+func (m *ParamAnalyzer) placement(pass *analysis.Pass, res chainResult) (string, bool) {
+	pkg, ok := m.structPackage(pass, res)
+	if ok {
+		return "place struct in package " + pkg, true
+	}
+	for _, p := range res.pkgs {
+		if !inModule(pass, p) {
+			return "", false
+		}
+	}
+	return "no package for struct is importable by every function of the chain without cycles", true
+}
+
+// knownPackages возвращает пакет и все пакеты, которые он импортирует прямо или косвенно, по путям
+func knownPackages(pkg *types.Package) map[string]*types.Package {
+	known := make(map[string]*types.Package)
+	var walk func(p *types.Package)
+	walk = func(p *types.Package) {
+		if _, ok := known[p.Path()]; ok {
+			return
+		}
+		known[p.Path()] = p
+		for _, imp := range p.Imports() {
+			walk(imp)
+		}
+	}
+	walk(pkg)
+	return known
+}
+
+// imports проверяет, что пакет импортирует пакет с путем path прямо или косвенно
+func imports(pkg *types.Package, path string) bool {
+	if pkg == nil {
+		return false
+	}
+	seen := NewSet[string]()
+	queue := []*types.Package{pkg}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		for _, imp := range p.Imports() {
+			if imp.Path() == path {
+				return true
+			}
+			if !seen.Has(imp.Path()) {
+				seen.Add(imp.Path())
+				queue = append(queue, imp)
+			}
+		}
+	}
+	return false
+}
+
+// canImport проверяет правило internal-пакетов: путь с элементом internal можно
+// импортировать только из дерева пакетов, корнем которого является родитель этого элемента
+func canImport(importer, path string) bool {
+	parts := strings.Split(path, "/")
+	for i := len(parts) - 1; i >= 0; i-- {
+		if parts[i] != "internal" {
+			continue
+		}
+		parent := strings.Join(parts[:i], "/")
+		if parent == "" {
+			return isStdPath(importer)
+		}
+		return importer == parent || strings.HasPrefix(importer, parent+"/")
+	}
+	return true
+}
+
+// inModule проверяет, что пакет относится к модулю анализируемого пакета,
+// то есть в нем можно разместить структуру
+func inModule(pass *analysis.Pass, path string) bool {
+	if pass.Module != nil && pass.Module.Path != "" {
+		return path == pass.Module.Path || strings.HasPrefix(path, pass.Module.Path+"/")
+	}
+	return !isStdPath(path)
+}
+
+// typePackages добавляет к списку пакеты именованных типов, из которых составлен тип
+func typePackages(t types.Type, pkgs []*types.Package) []*types.Package {
+	switch t := t.(type) {
+	case *types.Named:
+		if pkg := t.Obj().Pkg(); pkg != nil && !slices.Contains(pkgs, pkg) {
+			pkgs = append(pkgs, pkg)
+		}
+		for i := 0; i < t.TypeArgs().Len(); i++ {
+			pkgs = typePackages(t.TypeArgs().At(i), pkgs)
+		}
+	case *types.Alias:
+		pkgs = typePackages(types.Unalias(t), pkgs)
+	case *types.Pointer:
+		pkgs = typePackages(t.Elem(), pkgs)
+	case *types.Slice:
+		pkgs = typePackages(t.Elem(), pkgs)
+	case *types.Array:
+		pkgs = typePackages(t.Elem(), pkgs)
+	case *types.Chan:
+		pkgs = typePackages(t.Elem(), pkgs)
+	case *types.Map:
+		pkgs = typePackages(t.Key(), pkgs)
+		pkgs = typePackages(t.Elem(), pkgs)
+	}
+	return pkgs
+}
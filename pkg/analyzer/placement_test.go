@@ -0,0 +1,25 @@
+package analyzer
+
+import "testing"
+
+func TestCanImport(t *testing.T) {
+	tests := []struct {
+		importer string
+		path     string
+		expected bool
+	}{
+		{importer: "example.com/a", path: "example.com/b", expected: true},
+		{importer: "example.com/svc", path: "example.com/svc/internal/db", expected: true},
+		{importer: "example.com/svc/api", path: "example.com/svc/internal/db", expected: true},
+		{importer: "example.com/api", path: "example.com/svc/internal/db", expected: false},
+		{importer: "example.com/svcx", path: "example.com/svc/internal", expected: false},
+		{importer: "example.com/a", path: "internal/poll", expected: false},
+		{importer: "os", path: "internal/poll", expected: true},
+	}
+
+	for _, tt := range tests {
+		if got := canImport(tt.importer, tt.path); got != tt.expected {
+			t.Errorf("canImport(%q, %q) = %v, want %v", tt.importer, tt.path, got, tt.expected)
+		}
+	}
+}
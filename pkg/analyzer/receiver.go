@@ -0,0 +1,228 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// categoryReceiver категория диагностики для цепочек методов одного типа
+const categoryReceiver = "receiver"
+
+// receiverDiagnostic предлагает перенести группу параметров в поля получателя,
+// если все функции цепочки являются методами одного именованного типа.
+// Диагностика и исправление относятся к части цепочки part, которую можно исправить
+func (m *ParamAnalyzer) receiverDiagnostic(pass *analysis.Pass, res, part chainResult) (analysis.Diagnostic, bool) {
+	funcs := make([]*ast.FuncDecl, 0, len(res.callStack))
+	for _, k := range res.callStack {
+		m.ma.RLock()
+		f, ok := m.all[k]
+		m.ma.RUnlock()
+		if !ok {
+			return analysis.Diagnostic{}, false
+		}
+		funcs = append(funcs, f)
+	}
+
+	named, ok := m.receiverType(funcs)
+	if !ok {
+		return analysis.Diagnostic{}, false
+	}
+
+	d := analysis.Diagnostic{
+		Pos:      part.leafFunc.Pos(),
+		Category: categoryReceiver,
+		Message: fmt.Sprintf("move arguments to fields of %s: %s, for call stack: %s",
+			named.Obj().Name(), formatArgs(res.args), strings.Join(res.callStack, " -> ")),
+	}
+	if fix, ok := m.receiverFix(pass, part, named); ok {
+		d.SuggestedFixes = []analysis.SuggestedFix{fix}
+	}
+	return d, true
+}
+
+// receiverType возвращает именованный тип получателя, если все функции являются его методами
+func (m *ParamAnalyzer) receiverType(funcs []*ast.FuncDecl) (*types.Named, bool) {
+	var named *types.Named
+	for _, f := range funcs {
+		if f.Recv == nil || len(f.Recv.List) == 0 {
+			return nil, false
+		}
+
+		t := m.info.TypeOf(f.Recv.List[0].Type)
+		if t == nil {
+			return nil, false
+		}
+		if ptr, ok := t.(*types.Pointer); ok {
+			t = ptr.Elem()
+		}
+		n, ok := t.(*types.Named)
+		if !ok || (named != nil && n.Obj() != named.Obj()) {
+			return nil, false
+		}
+		named = n
+	}
+	return named, named != nil
+}
+
+// receiverFix строит исправление: группа параметров добавляется полями получателя,
+// корневой метод сохраняет сигнатуру и записывает параметры в поля, остальные методы
+// цепочки теряют параметры группы и читают значения из полей.
+// Исправление не строится, если его нельзя применить безопасно
+func (m *ParamAnalyzer) receiverFix(pass *analysis.Pass, res chainResult, named *types.Named) (analysis.SuggestedFix, bool) {
+	plan, ok := m.planChain(pass, res)
+	if !ok {
+		return analysis.SuggestedFix{}, false
+	}
+
+	st, ok := findStructType(pass, named.Obj())
+	if !ok {
+		return analysis.SuggestedFix{}, false
+	}
+	// Новые поля не должны совпадать с полями и методами получателя, в том числе встроенными
+	renamed := renameFields(plan.fields, func(name string) bool {
+		obj, _, _ := types.LookupFieldOrMethod(named, true, named.Obj().Pkg(), name)
+		return obj != nil
+	})
+
+	recvs := make([]*ast.Ident, 0, len(plan.funcs))
+	for _, f := range plan.funcs {
+		names := f.Recv.List[0].Names
+		if len(names) != 1 || names[0].Name == "_" {
+			return analysis.SuggestedFix{}, false
+		}
+		recvs = append(recvs, names[0])
+	}
+
+	edits := []analysis.TextEdit{structFieldsEdit(pass, st, plan.fields)}
+	for i, f := range plan.funcs {
+		params := plan.params[i]
+		if m.anyAssigned(f.Body, params) {
+			return analysis.SuggestedFix{}, false
+		}
+
+		// Вызовы следующего метода цепочки теряют аргументы группы
+		var deletes []analysis.TextEdit
+		if i < len(plan.funcs)-1 {
+			next := plan.funcs[i+1]
+			for _, callExpr := range plan.calls[i] {
+				if !m.forwards(callExpr, next, params, plan.params[i+1]) || !m.calledOn(callExpr, recvs[i]) {
+					return analysis.SuggestedFix{}, false
+				}
+				deletes = append(deletes, replaceArgs(callExpr, argPositions(next, plan.params[i+1]), "")...)
+			}
+		}
+		edits = append(edits, deletes...)
+
+		if i == 0 {
+			edits = append(edits, insertStmt(pass.Fset, f.Body, fieldsAssignment(recvs[0].Name, plan)))
+			continue
+		}
+
+		// Метод может вызываться не только из цепочки, в том числе из других пакетов
+		if ast.IsExported(f.Name.Name) || !m.onlyReferencedFrom(pass, f, plan.calls[i-1]) {
+			return analysis.SuggestedFix{}, false
+		}
+		// Вызовы через интерфейс не заполняют поля получателя
+		if ims, ok := m.implementedInterfaces(pass, f); !ok || len(ims) > 0 {
+			return analysis.SuggestedFix{}, false
+		}
+
+		repl := make([]string, 0, len(plan.fields))
+		for _, field := range plan.fields {
+			repl = append(repl, recvs[i].Name+"."+field.name)
+		}
+		edits = append(edits, analysis.TextEdit{
+			Pos:     f.Type.Params.Opening,
+			End:     f.Type.Params.Closing + 1,
+			NewText: []byte(formatParams(pass, f.Type.Params, argPositions(f, params), "", "")),
+		})
+		edits = append(edits, m.replaceUses(f.Body, params, repl, deletes)...)
+	}
+
+	// Типы новых полей могут требовать импортов в файле с объявлением получателя
+	imports, ok := importEdits(pass, edits, st.Pos(), plan.fields)
+	if !ok {
+		return analysis.SuggestedFix{}, false
+	}
+	edits = append(edits, imports...)
+
+	return analysis.SuggestedFix{
+		Message:   "Move arguments to fields of " + named.Obj().Name() + renamedString(renamed),
+		TextEdits: edits,
+	}, true
+}
+
+// calledOn проверяет, что метод вызывается на указанном получателе: recv.Method(...)
+func (m *ParamAnalyzer) calledOn(callExpr *ast.CallExpr, recv *ast.Ident) bool {
+	sel, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	ident, ok := ast.Unparen(sel.X).(*ast.Ident)
+	return ok && m.info.ObjectOf(ident) != nil && m.info.ObjectOf(ident) == m.info.ObjectOf(recv)
+}
+
+// fieldsAssignment возвращает присваивание параметров корневой функции полям получателя
+func fieldsAssignment(recv string, plan chainPlan) string {
+	lhs := make([]string, 0, len(plan.fields))
+	rhs := make([]string, 0, len(plan.fields))
+	for j, field := range plan.fields {
+		lhs = append(lhs, recv+"."+field.name)
+		rhs = append(rhs, plan.params[0][j].Name)
+	}
+	return strings.Join(lhs, ", ") + " = " + strings.Join(rhs, ", ")
+}
+
+// findStructType ищет объявление структуры для имени типа в файлах пакета
+func findStructType(pass *analysis.Pass, obj *types.TypeName) (*ast.StructType, bool) {
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts, ok := spec.(*ast.TypeSpec)
+				if !ok || pass.TypesInfo.Defs[ts.Name] != obj {
+					continue
+				}
+				st, ok := ts.Type.(*ast.StructType)
+				return st, ok
+			}
+		}
+	}
+	return nil, false
+}
+
+// structFieldsEdit добавляет поля в конец объявления структуры.
+// Объявление переформатируется, чтобы новые поля были выровнены вместе со старыми
+func structFieldsEdit(pass *analysis.Pass, st *ast.StructType, fields []structField) analysis.TextEdit {
+	tf := pass.Fset.File(st.Pos())
+	if src, ok := readSource(pass, tf); ok {
+		inner := strings.TrimRight(string(src[tf.Offset(st.Fields.Opening)+1:tf.Offset(st.Fields.Closing)]), " \t")
+		if !strings.HasSuffix(inner, "\n") {
+			inner += "\n"
+		}
+		text := gofmt("type _ struct {" + inner + fieldLines(pass.Fset, fields) + "}")
+		return analysis.TextEdit{
+			Pos:     st.Pos(),
+			End:     st.End(),
+			NewText: []byte(indentLines(strings.TrimPrefix(text, "type _ "), lineIndent(pass, st.Fields.Closing))),
+		}
+	}
+
+	text := fieldLines(pass.Fset, fields)
+	if pass.Fset.Position(st.Fields.Opening).Line == pass.Fset.Position(st.Fields.Closing).Line {
+		text = "\n" + text
+	}
+	return analysis.TextEdit{
+		Pos:     st.Fields.Closing,
+		End:     st.Fields.Closing,
+		NewText: []byte(text),
+	}
+}
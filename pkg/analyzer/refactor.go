@@ -0,0 +1,392 @@
+package analyzer
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// structField описывает поле структуры, в которую объединяется группа параметров
+type structField struct {
+	// name имя поля (имя параметра корневой функции цепочки)
+	name string
+	// typ тип поля из сигнатуры корневой функции
+	typ ast.Expr
+	// doc комментарии параметра на строках перед ним
+	doc []string
+	// comment комментарии параметра на его строке
+	comment []string
+	// imports импорты, на которые ссылается тип поля
+	imports []*types.PkgName
+}
+
+// chainPlan описывает соответствие параметров группы в функциях цепочки
+// и используется для построения исправлений
+type chainPlan struct {
+	// funcs функции цепочки от корня к листу
+	funcs []*ast.FuncDecl
+	// fields поля структуры в порядке объявления параметров корневой функции
+	fields []structField
+	// params параметры группы каждой функции в порядке полей
+	params [][]*ast.Ident
+	// calls вызовы следующей функции цепочки из каждой функции, кроме листа
+	calls [][]*ast.CallExpr
+}
+
+// planChain сопоставляет параметры группы во всех функциях цепочки.
+// Параметры сопоставляются по типу и порядковому номеру среди параметров этого типа.
+// Комментарии параметров корневой функции (а если их нет - первой функции цепочки,
+// где они есть) становятся комментариями полей структуры.
+// Возвращает false, если цепочку нельзя автоматически исправить, в том числе если в ней
+// есть обобщенные функции или методы обобщенных типов
+func (m *ParamAnalyzer) planChain(pass *analysis.Pass, chain chainResult) (chainPlan, bool) {
+	if len(chain.callStack) < 2 || totalParams(chain.args) == 0 {
+		return chainPlan{}, false
+	}
+
+	var plan chainPlan
+	seen := NewSet[string]()
+	for _, k := range chain.callStack {
+		// Рекурсивные цепочки не исправляем
+		if seen.Has(k) {
+			return chainPlan{}, false
+		}
+		seen.Add(k)
+
+		m.ma.RLock()
+		f, ok := m.all[k]
+		m.ma.RUnlock()
+		// Обобщенные функции не исправляем: структура получила бы параметры типа,
+		// а методы не могут иметь собственных параметров типа
+		if !ok || m.isGeneric(f) {
+			return chainPlan{}, false
+		}
+		plan.funcs = append(plan.funcs, f)
+	}
+
+	var rootKeys []string
+	for i, f := range plan.funcs {
+		keys, idents, typs := m.groupParams(f, chain.args)
+		if len(idents) != totalParams(chain.args) {
+			return chainPlan{}, false
+		}
+
+		comments := paramComments(pass, f)
+		if i == 0 {
+			rootKeys = keys
+			for j, ident := range idents {
+				plan.fields = append(plan.fields, structField{
+					name:    ident.Name,
+					typ:     typs[j],
+					doc:     commentText(comments[ident].doc),
+					comment: commentText(comments[ident].line),
+					imports: m.typeImports(typs[j]),
+				})
+			}
+			plan.params = append(plan.params, idents)
+			continue
+		}
+
+		byKey := make(map[string]*ast.Ident, len(keys))
+		for j, k := range keys {
+			byKey[k] = idents[j]
+		}
+		ordered := make([]*ast.Ident, 0, len(rootKeys))
+		for _, k := range rootKeys {
+			ordered = append(ordered, byKey[k])
+		}
+		for j, p := range ordered {
+			field := &plan.fields[j]
+			if len(field.doc) == 0 && len(field.comment) == 0 {
+				field.doc = commentText(comments[p].doc)
+				field.comment = commentText(comments[p].line)
+			}
+		}
+		plan.params = append(plan.params, ordered)
+	}
+
+	for i := 0; i < len(plan.funcs)-1; i++ {
+		calls := m.callsTo(plan.funcs[i], plan.funcs[i+1])
+		if len(calls) == 0 {
+			return chainPlan{}, false
+		}
+		plan.calls = append(plan.calls, calls)
+	}
+
+	return plan, true
+}
+
+// isGeneric проверяет, что функция или тип ее получателя имеют параметры типа
+func (m *ParamAnalyzer) isGeneric(f *ast.FuncDecl) bool {
+	if f.Type.TypeParams != nil {
+		return true
+	}
+	fn, ok := m.info.Defs[f.Name].(*types.Func)
+	return ok && fn.Type().(*types.Signature).RecvTypeParams().Len() > 0
+}
+
+// groupParams возвращает параметры функции, входящие в группу, в порядке объявления:
+// ключи вида "тип#номер", идентификаторы и выражения типов
+func (m *ParamAnalyzer) groupParams(f *ast.FuncDecl, group map[string]int) ([]string, []*ast.Ident, []ast.Expr) {
+	used := make(map[string]int)
+	var keys []string
+	var idents []*ast.Ident
+	var typs []ast.Expr
+	for _, field := range f.Type.Params.List {
+		t := m.info.TypeOf(field.Type)
+		if t == nil {
+			continue
+		}
+
+		typeStr := t.String()
+		for _, name := range field.Names {
+			if used[typeStr] >= group[typeStr] {
+				break
+			}
+			used[typeStr]++
+			if name.Name == "_" {
+				continue
+			}
+			keys = append(keys, fmt.Sprintf("%s#%d", typeStr, used[typeStr]))
+			idents = append(idents, name)
+			typs = append(typs, field.Type)
+		}
+	}
+	return keys, idents, typs
+}
+
+// groupFields возвращает поля структуры для параметров функции, входящих в группу
+func (m *ParamAnalyzer) groupFields(f *ast.FuncDecl, group map[string]int) []structField {
+	_, idents, typs := m.groupParams(f, group)
+	fields := make([]structField, 0, len(idents))
+	for j, ident := range idents {
+		fields = append(fields, structField{name: ident.Name, typ: typs[j]})
+	}
+	return fields
+}
+
+// reorder переставляет поля структуры и соответствующие им параметры функций цепочки
+func (p *chainPlan) reorder(order []int) {
+	fields := make([]structField, 0, len(order))
+	for _, idx := range order {
+		fields = append(fields, p.fields[idx])
+	}
+	p.fields = fields
+
+	for i, params := range p.params {
+		ordered := make([]*ast.Ident, 0, len(order))
+		for _, idx := range order {
+			ordered = append(ordered, params[idx])
+		}
+		p.params[i] = ordered
+	}
+}
+
+// callsTo возвращает вызовы функции callee в теле функции caller
+func (m *ParamAnalyzer) callsTo(caller, callee *ast.FuncDecl) []*ast.CallExpr {
+	calleeKey := m.funcDeclToKey(callee)
+
+	var calls []*ast.CallExpr
+	ast.Inspect(caller.Body, func(node ast.Node) bool {
+		if callExpr, ok := node.(*ast.CallExpr); ok {
+			if k, ok := m.callExprToKey(callExpr); ok && k == calleeKey {
+				calls = append(calls, callExpr)
+			}
+		}
+		return true
+	})
+	return calls
+}
+
+// forwards проверяет, что вызов передает параметры группы вызывающей функции
+// в соответствующие параметры группы вызываемой функции без изменений
+func (m *ParamAnalyzer) forwards(callExpr *ast.CallExpr, callee *ast.FuncDecl, callerParams, calleeParams []*ast.Ident) bool {
+	if callExpr.Ellipsis.IsValid() {
+		return false
+	}
+
+	index := paramIndex(callee)
+	for j, p := range calleeParams {
+		idx, ok := index[p]
+		if !ok || idx >= len(callExpr.Args) {
+			return false
+		}
+		ident, ok := ast.Unparen(callExpr.Args[idx]).(*ast.Ident)
+		if !ok || m.info.ObjectOf(ident) == nil || m.info.ObjectOf(ident) != m.info.ObjectOf(callerParams[j]) {
+			return false
+		}
+	}
+	return true
+}
+
+// isAssigned проверяет, что параметр изменяется или у него берется адрес в теле функции
+func (m *ParamAnalyzer) isAssigned(body *ast.BlockStmt, param *ast.Ident) bool {
+	obj := m.info.ObjectOf(param)
+	if obj == nil {
+		return true
+	}
+
+	isParam := func(expr ast.Expr) bool {
+		ident, ok := ast.Unparen(expr).(*ast.Ident)
+		return ok && m.info.ObjectOf(ident) == obj
+	}
+
+	assigned := false
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				if isParam(lhs) {
+					assigned = true
+				}
+			}
+		case *ast.IncDecStmt:
+			if isParam(n.X) {
+				assigned = true
+			}
+		case *ast.UnaryExpr:
+			if n.Op == token.AND && isParam(n.X) {
+				assigned = true
+			}
+		}
+		return !assigned
+	})
+	return assigned
+}
+
+// anyAssigned проверяет, что изменяется хотя бы один из параметров
+func (m *ParamAnalyzer) anyAssigned(body *ast.BlockStmt, params []*ast.Ident) bool {
+	for _, p := range params {
+		if m.isAssigned(body, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// paramIndex возвращает позиции именованных параметров функции
+func paramIndex(f *ast.FuncDecl) map[*ast.Ident]int {
+	index := make(map[*ast.Ident]int)
+	idx := 0
+	for _, field := range f.Type.Params.List {
+		if len(field.Names) == 0 {
+			idx++
+			continue
+		}
+		for _, name := range field.Names {
+			index[name] = idx
+			idx++
+		}
+	}
+	return index
+}
+
+// replaceUses заменяет использования параметров в узле на переданные выражения,
+// пропуская использования внутри удаляемых фрагментов skip
+func (m *ParamAnalyzer) replaceUses(node ast.Node, params []*ast.Ident, repl []string, skip []analysis.TextEdit) []analysis.TextEdit {
+	objs := make(map[types.Object]string, len(params))
+	for j, p := range params {
+		if obj := m.info.ObjectOf(p); obj != nil {
+			objs[obj] = repl[j]
+		}
+	}
+
+	var edits []analysis.TextEdit
+	ast.Inspect(node, func(n ast.Node) bool {
+		ident, ok := n.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		r, ok := objs[m.info.Uses[ident]]
+		if !ok || covered(skip, ident) {
+			return true
+		}
+		edits = append(edits, analysis.TextEdit{Pos: ident.Pos(), End: ident.End(), NewText: []byte(r)})
+		return true
+	})
+	return edits
+}
+
+// covered проверяет, что узел целиком лежит внутри одного из фрагментов
+func covered(edits []analysis.TextEdit, node ast.Node) bool {
+	for _, e := range edits {
+		if e.Pos <= node.Pos() && node.End() <= e.End {
+			return true
+		}
+	}
+	return false
+}
+
+// replaceArgs удаляет аргументы вызова с указанными позициями вместе с разделяющими запятыми.
+// Если передан replacement, он подставляется вместо первого удаленного аргумента
+func replaceArgs(callExpr *ast.CallExpr, drop set[int], replacement string) []analysis.TextEdit {
+	args := callExpr.Args
+	var edits []analysis.TextEdit
+	for i := 0; i < len(args); i++ {
+		if !drop.Has(i) {
+			continue
+		}
+		j := i
+		for j+1 < len(args) && drop.Has(j+1) {
+			j++
+		}
+
+		var edit analysis.TextEdit
+		switch {
+		case j+1 < len(args):
+			edit = analysis.TextEdit{Pos: args[i].Pos(), End: args[j+1].Pos()}
+			if replacement != "" {
+				edit.NewText = []byte(replacement + ", ")
+			}
+		case i > 0:
+			edit = analysis.TextEdit{Pos: args[i-1].End(), End: args[j].End()}
+			if replacement != "" {
+				edit.NewText = []byte(", " + replacement)
+			}
+		default:
+			edit = analysis.TextEdit{Pos: args[i].Pos(), End: args[j].End(), NewText: []byte(replacement)}
+		}
+		edits = append(edits, edit)
+		replacement = ""
+		i = j
+	}
+	return edits
+}
+
+// argPositions возвращает позиции аргументов, соответствующих параметрам функции
+func argPositions(f *ast.FuncDecl, params []*ast.Ident) set[int] {
+	index := paramIndex(f)
+	positions := NewSet[int]()
+	for _, p := range params {
+		positions.Add(index[p])
+	}
+	return positions
+}
+
+// insertStmt вставляет оператор в начало тела функции
+func insertStmt(fset *token.FileSet, body *ast.BlockStmt, stmt string) analysis.TextEdit {
+	next := body.Rbrace
+	if len(body.List) > 0 {
+		next = body.List[0].Pos()
+	}
+
+	text := "\n\t" + stmt
+	if fset.Position(body.Lbrace).Line == fset.Position(next).Line {
+		text += "\n"
+	}
+	return analysis.TextEdit{Pos: body.Lbrace + 1, End: body.Lbrace + 1, NewText: []byte(text)}
+}
+
+// exprText печатает выражение в виде исходного кода
+func exprText(fset *token.FileSet, expr ast.Expr) string {
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, expr); err != nil {
+		return types.ExprString(expr)
+	}
+	return buf.String()
+}
@@ -0,0 +1,95 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Result результат анализатора параметров для пакета. Позволяет другим анализаторам
+// использовать найденные цепочки, например для проверки архитектурных правил
+type Result struct {
+	// Chains цепочки, о которых сообщил анализатор, в порядке диагностик
+	Chains []Chain
+}
+
+// Chain цепочка вызовов, через все функции которой передается группа параметров
+type Chain struct {
+	// Funcs функции цепочки от корня к последней функции.
+	// Цепочка может продолжаться в функциях других пакетов
+	Funcs []ChainFunc
+	// Params параметры группы в порядке объявления в корневой функции
+	Params []Param
+	// Pos позиция диагностики о цепочке. Для цепочки из одних флагов это корень,
+	// в котором предлагается структура флагов
+	Pos token.Pos
+}
+
+// ChainFunc функция цепочки
+type ChainFunc struct {
+	// Name имя функции в пакете: Func или Type.Method
+	Name string
+	// PkgPath путь пакета функции
+	PkgPath string
+	// Decl объявление функции или nil для функций других пакетов
+	Decl *ast.FuncDecl
+}
+
+// Param параметр группы
+type Param struct {
+	// Name имя параметра в корневой функции или пустая строка для безымянного параметра
+	Name string
+	// Type тип параметра
+	Type types.Type
+}
+
+// chainOf преобразует найденную цепочку в цепочку результата
+func (m *ParamAnalyzer) chainOf(pass *analysis.Pass, res chainResult, pos token.Pos) Chain {
+	chain := Chain{Pos: pos}
+	m.ma.RLock()
+	for i, k := range res.callStack {
+		fn := ChainFunc{Name: k, PkgPath: pass.Pkg.Path()}
+		if i < len(res.pkgs) {
+			fn.PkgPath = res.pkgs[i]
+		}
+		if fn.PkgPath == pass.Pkg.Path() {
+			fn.Decl = m.all[k]
+		}
+		chain.Funcs = append(chain.Funcs, fn)
+	}
+	m.ma.RUnlock()
+
+	if root := chain.Funcs[0].Decl; root != nil {
+		chain.Params = m.groupOf(root, res.args)
+	}
+	return chain
+}
+
+// groupOf возвращает параметры функции, входящие в группу, в порядке объявления
+func (m *ParamAnalyzer) groupOf(f *ast.FuncDecl, group map[string]int) []Param {
+	used := make(map[string]int)
+	var params []Param
+	for _, field := range f.Type.Params.List {
+		t := m.info.TypeOf(field.Type)
+		if t == nil {
+			continue
+		}
+		names := []string{""}
+		if len(field.Names) > 0 {
+			names = names[:0]
+			for _, name := range field.Names {
+				names = append(names, name.Name)
+			}
+		}
+		for _, name := range names {
+			if used[t.String()] >= group[t.String()] {
+				break
+			}
+			used[t.String()]++
+			params = append(params, Param{Name: name, Type: t})
+		}
+	}
+	return params
+}
@@ -0,0 +1,573 @@
+package analyzer
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// fieldComments описывает комментарии поля списка параметров
+type fieldComments struct {
+	// doc комментарии на строках перед полем
+	doc []*ast.Comment
+	// line комментарии внутри поля и после него на той же строке
+	line []*ast.Comment
+	// blank перед полем стоит пустая строка
+	blank bool
+}
+
+// fileOf возвращает файл пакета, содержащий позицию
+func fileOf(pass *analysis.Pass, pos token.Pos) *ast.File {
+	for _, file := range pass.Files {
+		if file.FileStart <= pos && pos <= file.FileEnd {
+			return file
+		}
+	}
+	return nil
+}
+
+// readSource возвращает исходный код файла
+func readSource(pass *analysis.Pass, tf *token.File) ([]byte, bool) {
+	read := os.ReadFile
+	if pass.ReadFile != nil {
+		read = pass.ReadFile
+	}
+	src, err := read(tf.Name())
+	if err != nil || len(src) != tf.Size() {
+		return nil, false
+	}
+	return src, true
+}
+
+// lineIndent возвращает отступ строки, на которой находится позиция
+func lineIndent(pass *analysis.Pass, pos token.Pos) string {
+	tf := pass.Fset.File(pos)
+	if tf == nil {
+		return ""
+	}
+	src, ok := readSource(pass, tf)
+	if !ok {
+		return ""
+	}
+	start := tf.Offset(tf.LineStart(tf.Line(pos)))
+	end := start
+	for end < len(src) && (src[end] == '\t' || src[end] == ' ') {
+		end++
+	}
+	return string(src[start:end])
+}
+
+// listComments распределяет комментарии внутри списка параметров по его полям.
+// Комментарий на строке поля относится к этому полю, комментарий на отдельной
+// строке - к следующему полю. Комментарии после последнего поля на отдельных
+// строках возвращаются отдельно
+func listComments(pass *analysis.Pass, list *ast.FieldList) ([]fieldComments, []*ast.Comment) {
+	comments := make([]fieldComments, len(list.List))
+	file := fileOf(pass, list.Opening)
+	if file == nil || !list.Opening.IsValid() || !list.Closing.IsValid() {
+		return comments, nil
+	}
+
+	line := func(pos token.Pos) int { return pass.Fset.Position(pos).Line }
+
+	var tail []*ast.Comment
+	for _, cg := range file.Comments {
+		if cg.Pos() < list.Opening || cg.End() > list.Closing {
+			continue
+		}
+		for _, c := range cg.List {
+			k := -1
+			for i, field := range list.List {
+				if field.Pos() <= c.Pos() {
+					k = i
+				}
+			}
+			switch {
+			case k >= 0 && (c.Pos() < list.List[k].End() || line(c.Pos()) == line(list.List[k].End())):
+				comments[k].line = append(comments[k].line, c)
+			case k+1 < len(list.List):
+				comments[k+1].doc = append(comments[k+1].doc, c)
+			default:
+				tail = append(tail, c)
+			}
+		}
+	}
+
+	for i := 1; i < len(list.List); i++ {
+		start := list.List[i].Pos()
+		if len(comments[i].doc) > 0 {
+			start = comments[i].doc[0].Pos()
+		}
+		comments[i].blank = line(start) > line(list.List[i-1].End())+1
+	}
+	return comments, tail
+}
+
+// paramComments возвращает комментарии параметров функции. Комментарии поля
+// с несколькими именами относятся к первому из них
+func paramComments(pass *analysis.Pass, f *ast.FuncDecl) map[*ast.Ident]fieldComments {
+	comments, _ := listComments(pass, f.Type.Params)
+	byParam := make(map[*ast.Ident]fieldComments)
+	for i, field := range f.Type.Params.List {
+		if len(field.Names) > 0 {
+			byParam[field.Names[0]] = comments[i]
+		}
+	}
+	return byParam
+}
+
+// commentText возвращает тексты комментариев
+func commentText(comments []*ast.Comment) []string {
+	texts := make([]string, 0, len(comments))
+	for _, c := range comments {
+		texts = append(texts, c.Text)
+	}
+	return texts
+}
+
+// typeImports возвращает импорты, на которые ссылается выражение типа
+func (m *ParamAnalyzer) typeImports(typ ast.Expr) []*types.PkgName {
+	var imports []*types.PkgName
+	ast.Inspect(typ, func(node ast.Node) bool {
+		if ident, ok := node.(*ast.Ident); ok {
+			if pkgName, ok := m.info.Uses[ident].(*types.PkgName); ok {
+				imports = append(imports, pkgName)
+			}
+		}
+		return true
+	})
+	return imports
+}
+
+// paramPart элемент нового списка параметров вместе с комментариями исходного поля
+type paramPart struct {
+	text string
+	fieldComments
+}
+
+// formatParams печатает список параметров без параметров с позициями drop.
+// Если передан тип typ, параметр name этого типа подставляется вместо первого удаленного.
+// В списках без имен параметров подставляется только тип.
+// Комментарии оставшихся параметров сохраняются, многострочный список остается
+// многострочным и выравнивается так же, как это сделал бы gofmt
+func formatParams(pass *analysis.Pass, list *ast.FieldList, drop set[int], name, typ string) string {
+	replacement := typ
+	if typ != "" && len(list.List) > 0 && len(list.List[0].Names) > 0 {
+		replacement = name + " " + typ
+	}
+	comments, tail := listComments(pass, list)
+
+	var parts []paramPart
+	idx := 0
+	for i, field := range list.List {
+		text := exprText(pass.Fset, field.Type)
+		// Комментарии поля переходят к первому элементу, который из него получился
+		pending := comments[i]
+		add := func(text string, keep bool) {
+			part := paramPart{text: text}
+			part.blank = pending.blank
+			if keep {
+				part.fieldComments = pending
+				pending = fieldComments{}
+			}
+			pending.blank = false
+			parts = append(parts, part)
+		}
+
+		if len(field.Names) == 0 {
+			switch {
+			case !drop.Has(idx):
+				add(text, true)
+			case replacement != "":
+				add(replacement, false)
+				replacement = ""
+			}
+			idx++
+			continue
+		}
+
+		var names []string
+		flush := func() {
+			if len(names) > 0 {
+				add(strings.Join(names, ", ")+" "+text, true)
+				names = nil
+			}
+		}
+		for _, n := range field.Names {
+			if !drop.Has(idx) {
+				names = append(names, n.Name)
+			} else if replacement != "" {
+				flush()
+				add(replacement, false)
+				replacement = ""
+			}
+			idx++
+		}
+		flush()
+	}
+
+	line := func(pos token.Pos) int { return pass.Fset.Position(pos).Line }
+	if !list.Opening.IsValid() || line(list.Opening) == line(list.Closing) {
+		texts := make([]string, 0, len(parts))
+		for _, part := range parts {
+			words := append(commentText(part.doc), part.text)
+			texts = append(texts, strings.Join(append(words, commentText(part.line)...), " "))
+		}
+		return "(" + strings.Join(texts, ", ") + ")"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("func _(\n")
+	for i, part := range parts {
+		if part.blank && i > 0 {
+			sb.WriteString("\n")
+		}
+		for _, c := range part.doc {
+			sb.WriteString("\t" + c.Text + "\n")
+		}
+		sb.WriteString("\t" + part.text + ",")
+		for _, c := range part.line {
+			sb.WriteString(" " + c.Text)
+		}
+		sb.WriteString("\n")
+	}
+	for _, c := range tail {
+		sb.WriteString("\t" + c.Text + "\n")
+	}
+	sb.WriteString(")")
+
+	text := gofmt(sb.String())
+	return indentLines(strings.TrimPrefix(text, "func _"), lineIndent(pass, list.Closing))
+}
+
+// gofmt форматирует фрагмент исходного кода. Если фрагмент не разбирается, он возвращается без изменений
+func gofmt(src string) string {
+	formatted, err := format.Source([]byte(src))
+	if err != nil {
+		return src
+	}
+	return strings.TrimSpace(string(formatted))
+}
+
+// indentLines добавляет отступ ко всем строкам текста, кроме первой
+func indentLines(text, indent string) string {
+	if indent == "" {
+		return text
+	}
+	lines := strings.Split(text, "\n")
+	for i := 1; i < len(lines); i++ {
+		if lines[i] != "" {
+			lines[i] = indent + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// fieldLines печатает поля структуры вместе с их комментариями
+func fieldLines(fset *token.FileSet, fields []structField) string {
+	var sb strings.Builder
+	for _, field := range fields {
+		for _, doc := range field.doc {
+			sb.WriteString("\t" + doc + "\n")
+		}
+		sb.WriteString("\t" + field.name + " " + exprText(fset, field.typ))
+		for _, c := range field.comment {
+			sb.WriteString(" " + c)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// applyTextEdits применяет непересекающиеся правки к содержимому файла
+func applyTextEdits(tf *token.File, src []byte, edits []analysis.TextEdit) ([]byte, error) {
+	edits = append([]analysis.TextEdit(nil), edits...)
+	sort.SliceStable(edits, func(i, j int) bool {
+		if edits[i].Pos != edits[j].Pos {
+			return edits[i].Pos < edits[j].Pos
+		}
+		return edits[i].End < edits[j].End
+	})
+
+	var out []byte
+	last := 0
+	for _, edit := range edits {
+		end := edit.End
+		if !end.IsValid() {
+			end = edit.Pos
+		}
+		start, stop := tf.Offset(edit.Pos), tf.Offset(end)
+		if start < last || stop < start {
+			return nil, fmt.Errorf("overlapping edit at offset %d", start)
+		}
+		out = append(out, src[last:start]...)
+		out = append(out, edit.NewText...)
+		last = stop
+	}
+	return append(out, src[last:]...), nil
+}
+
+// importEdits приводит импорты затронутых правками файлов в соответствие с новым кодом:
+// удаляет импорты, которые перестали использоваться, и добавляет в файл с позицией pos
+// импорты пакетов из типов полей fields. Возвращает false, если нужный импорт
+// конфликтует с импортами файла
+func importEdits(pass *analysis.Pass, edits []analysis.TextEdit, pos token.Pos, fields []structField) ([]analysis.TextEdit, bool) {
+	byFile := make(map[*token.File][]analysis.TextEdit)
+	var files []*token.File
+	for _, edit := range edits {
+		tf := pass.Fset.File(edit.Pos)
+		if tf == nil {
+			continue
+		}
+		if _, ok := byFile[tf]; !ok {
+			files = append(files, tf)
+		}
+		byFile[tf] = append(byFile[tf], edit)
+	}
+
+	var result []analysis.TextEdit
+	for _, tf := range files {
+		file := fileOf(pass, token.Pos(tf.Base()))
+		src, ok := readSource(pass, tf)
+		if file == nil || !ok {
+			continue
+		}
+		out, err := applyTextEdits(tf, src, byFile[tf])
+		if err != nil {
+			continue
+		}
+		changed, err := parser.ParseFile(token.NewFileSet(), tf.Name(), out, parser.SkipObjectResolution)
+		if err != nil {
+			continue
+		}
+
+		used := NewSet[string]()
+		ast.Inspect(changed, func(node ast.Node) bool {
+			if sel, ok := node.(*ast.SelectorExpr); ok {
+				if ident, ok := sel.X.(*ast.Ident); ok {
+					used.Add(ident.Name)
+				}
+			}
+			return true
+		})
+		result = append(result, removeImports(pass, tf, src, file, used)...)
+
+		if tf == pass.Fset.File(pos) {
+			add, ok := addImports(pass, tf, file, fields)
+			if !ok {
+				return nil, false
+			}
+			result = append(result, add...)
+		}
+	}
+	return result, true
+}
+
+// importName возвращает имя, под которым импорт доступен в файле
+func importName(pass *analysis.Pass, spec *ast.ImportSpec) string {
+	if spec.Name != nil {
+		return spec.Name.Name
+	}
+	if pkgName := pass.TypesInfo.PkgNameOf(spec); pkgName != nil {
+		return pkgName.Name()
+	}
+	return ""
+}
+
+// removeImports удаляет импорты файла, имена которых не используются в новом коде
+func removeImports(pass *analysis.Pass, tf *token.File, src []byte, file *ast.File, used set[string]) []analysis.TextEdit {
+	var edits []analysis.TextEdit
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+
+		var unused []*ast.ImportSpec
+		for _, spec := range gen.Specs {
+			spec := spec.(*ast.ImportSpec)
+			name := importName(pass, spec)
+			if name == "" || name == "_" || name == "." || spec.Path.Value == `"C"` || used.Has(name) {
+				continue
+			}
+			unused = append(unused, spec)
+		}
+		if len(unused) == 0 {
+			continue
+		}
+
+		// Объявление удаляется целиком вместе с пустыми строками после него
+		if len(unused) == len(gen.Specs) {
+			start := gen.Pos()
+			if gen.Doc != nil {
+				start = gen.Doc.Pos()
+			}
+			end := tf.Offset(gen.End())
+			for end < len(src) && (src[end] == '\n' || src[end] == ' ' || src[end] == '\t') {
+				end++
+			}
+			edits = append(edits, analysis.TextEdit{Pos: start, End: tf.Pos(end)})
+			continue
+		}
+
+		for _, spec := range unused {
+			start := spec.Pos()
+			if spec.Doc != nil {
+				start = spec.Doc.Pos()
+			}
+			startOff, endOff := tf.Offset(tf.LineStart(tf.Line(start))), lineEnd(src, tf.Offset(spec.End()))
+			// Удаление не должно оставлять две пустые строки подряд или пустую строку у скобки
+			prev := prevLine(src, startOff)
+			next := strings.TrimSpace(string(src[endOff:lineEnd(src, endOff)]))
+			switch {
+			case next == "" && (prev == "" || strings.HasSuffix(prev, "(")):
+				endOff = lineEnd(src, endOff)
+			case prev == "" && strings.HasPrefix(next, ")"):
+				startOff -= len(prevLineRaw(src, startOff))
+			}
+			edits = append(edits, analysis.TextEdit{Pos: tf.Pos(startOff), End: tf.Pos(endOff)})
+		}
+	}
+	return edits
+}
+
+// lineEnd возвращает смещение начала строки, следующей за смещением off
+func lineEnd(src []byte, off int) int {
+	if i := bytes.IndexByte(src[off:], '\n'); i >= 0 {
+		return off + i + 1
+	}
+	return len(src)
+}
+
+// prevLineRaw возвращает строку перед строкой, начинающейся со смещения off, вместе с переводом строки
+func prevLineRaw(src []byte, off int) string {
+	if off == 0 {
+		return ""
+	}
+	start := bytes.LastIndexByte(src[:off-1], '\n') + 1
+	return string(src[start:off])
+}
+
+// prevLine возвращает строку перед строкой, начинающейся со смещения off, без пробелов по краям
+func prevLine(src []byte, off int) string {
+	return strings.TrimSpace(prevLineRaw(src, off))
+}
+
+// addImports добавляет в файл импорты пакетов из типов полей.
+// Возвращает false, если путь импортирован под другим именем или имя занято другим импортом
+func addImports(pass *analysis.Pass, tf *token.File, file *ast.File, fields []structField) ([]analysis.TextEdit, bool) {
+	byPath := make(map[string]string)
+	byName := make(map[string]string)
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		name := importName(pass, spec)
+		byPath[path] = name
+		byName[name] = path
+	}
+
+	var missing []*types.PkgName
+	for _, field := range fields {
+		for _, pkgName := range field.imports {
+			path := pkgName.Imported().Path()
+			if name, ok := byPath[path]; ok {
+				if name != pkgName.Name() {
+					return nil, false
+				}
+				continue
+			}
+			if _, ok := byName[pkgName.Name()]; ok {
+				return nil, false
+			}
+			byPath[path] = pkgName.Name()
+			byName[pkgName.Name()] = path
+			missing = append(missing, pkgName)
+		}
+	}
+
+	var edits []analysis.TextEdit
+	for _, pkgName := range missing {
+		edits = append(edits, addImport(tf, file, pkgName))
+	}
+	return edits, true
+}
+
+// addImport возвращает правку, которая добавляет импорт в файл. Импорт попадает
+// в группу стандартной библиотеки или сторонних пакетов первого объявления со скобками
+// с сохранением порядка, иначе добавляется отдельным объявлением
+func addImport(tf *token.File, file *ast.File, pkgName *types.PkgName) analysis.TextEdit {
+	path := pkgName.Imported().Path()
+	spec := strconv.Quote(path)
+	if pkgName.Name() != pkgName.Imported().Name() {
+		spec = pkgName.Name() + " " + spec
+	}
+
+	var last *ast.GenDecl
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		last = gen
+		if !gen.Lparen.IsValid() {
+			continue
+		}
+
+		// Группы импортов разделяются пустыми строками
+		var groups [][]*ast.ImportSpec
+		prevLine := 0
+		for _, s := range gen.Specs {
+			s := s.(*ast.ImportSpec)
+			if len(groups) == 0 || tf.Line(s.Pos()) > prevLine+1 {
+				groups = append(groups, nil)
+			}
+			groups[len(groups)-1] = append(groups[len(groups)-1], s)
+			prevLine = tf.Line(s.End())
+		}
+		for _, group := range groups {
+			if first, _ := strconv.Unquote(group[0].Path.Value); isStdPath(first) != isStdPath(path) {
+				continue
+			}
+			for _, s := range group {
+				if s.Path.Value > strconv.Quote(path) {
+					return insertLine(tf, s.Pos(), "\t"+spec)
+				}
+			}
+			return insertLine(tf, tf.LineStart(tf.Line(group[len(group)-1].End())+1), "\t"+spec)
+		}
+		text := "\t" + spec
+		if len(groups) > 0 {
+			text = "\n" + text
+		}
+		return insertLine(tf, gen.Rparen, text)
+	}
+
+	if last != nil {
+		return analysis.TextEdit{Pos: last.End(), End: last.End(), NewText: []byte("\nimport " + spec)}
+	}
+	return analysis.TextEdit{Pos: file.Name.End(), End: file.Name.End(), NewText: []byte("\n\nimport " + spec)}
+}
+
+// insertLine вставляет строку перед строкой, на которой находится позиция
+func insertLine(tf *token.File, pos token.Pos, text string) analysis.TextEdit {
+	start := tf.LineStart(tf.Line(pos))
+	return analysis.TextEdit{Pos: start, End: start, NewText: []byte(text + "\n")}
+}
+
+// isStdPath проверяет, что путь импорта относится к стандартной библиотеке
+func isStdPath(path string) bool {
+	first, _, _ := strings.Cut(path, "/")
+	return !strings.Contains(first, ".")
+}
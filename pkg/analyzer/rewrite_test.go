@@ -0,0 +1,76 @@
+package analyzer
+
+import (
+	"go/format"
+	"go/token"
+	"os"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestSuggestedFixesAreFormatted(t *testing.T) {
+	testdata := analysistest.TestData()
+	compat := DefaultOptions()
+	compat.CompatWrappers = true
+
+	tests := []struct {
+		pkg      string
+		analyzer *analysis.Analyzer
+	}{
+		{pkg: "receiver", analyzer: Analyzer()},
+		{pkg: "methodobject", analyzer: Analyzer()},
+		{pkg: "layout", analyzer: Analyzer()},
+		{pkg: "fieldorder", analyzer: Analyzer()},
+		{pkg: "interfaces", analyzer: Analyzer()},
+		{pkg: "rewrite", analyzer: Analyzer()},
+		{pkg: "names", analyzer: Analyzer()},
+		{pkg: "fixedsig", analyzer: Analyzer()},
+		{pkg: "compat", analyzer: AnalyzerWithOptions(compat)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pkg, func(t *testing.T) {
+			for _, res := range analysistest.Run(t, testdata, tt.analyzer, tt.pkg) {
+				for _, d := range res.Diagnostics {
+					// -fix применяет все исправления диагностики, поэтому альтернатив быть не должно
+					if len(d.SuggestedFixes) > 1 {
+						t.Errorf("%s: %d fixes for %q", res.Pass.Fset.Position(d.Pos), len(d.SuggestedFixes), d.Message)
+					}
+					for _, fix := range d.SuggestedFixes {
+						checkFormatted(t, res.Pass.Fset, fix)
+					}
+				}
+			}
+		})
+	}
+}
+
+// checkFormatted применяет исправление без форматирования и проверяет, что результат не меняет gofmt
+func checkFormatted(t *testing.T, fset *token.FileSet, fix analysis.SuggestedFix) {
+	t.Helper()
+
+	byFile := make(map[*token.File][]analysis.TextEdit)
+	for _, edit := range fix.TextEdits {
+		tf := fset.File(edit.Pos)
+		byFile[tf] = append(byFile[tf], edit)
+	}
+	for tf, edits := range byFile {
+		src, err := os.ReadFile(tf.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+		out, err := applyTextEdits(tf, src, edits)
+		if err != nil {
+			t.Fatalf("%s: %v", fix.Message, err)
+		}
+		formatted, err := format.Source(out)
+		if err != nil {
+			t.Fatalf("%s: %v\n%s", fix.Message, err, out)
+		}
+		if string(formatted) != string(out) {
+			t.Errorf("%s: %s is not gofmt-clean:\n%s", fix.Message, tf.Name(), out)
+		}
+	}
+}
@@ -48,3 +48,18 @@ func (s set[T]) Add(item T) {
 
 	s[item] = struct{}{}
 }
+
+// IsSubset проверяет, что все элементы текущего множества содержатся в переданном.
+func (s set[T]) IsSubset(other set[T]) bool {
+	if len(s) > len(other) {
+		return false
+	}
+
+	for v := range s {
+		if !other.Has(v) {
+			return false
+		}
+	}
+
+	return true
+}
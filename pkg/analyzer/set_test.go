@@ -65,4 +65,22 @@ func TestSet(t *testing.T) {
 			t.Errorf("expected Has to return false for nil set")
 		}
 	})
+
+	t.Run("IsSubset returns true for contained set", func(t *testing.T) {
+		s1 := NewSet(2, 3)
+		s2 := NewSet(1, 2, 3)
+		if !s1.IsSubset(s2) {
+			t.Errorf("expected {2, 3} to be a subset of {1, 2, 3}")
+		}
+		if s2.IsSubset(s1) {
+			t.Errorf("expected {1, 2, 3} not to be a subset of {2, 3}")
+		}
+	})
+
+	t.Run("IsSubset of empty set is true", func(t *testing.T) {
+		var s1 set[int]
+		if !s1.IsSubset(NewSet(1)) {
+			t.Errorf("expected empty set to be a subset of any set")
+		}
+	})
 }
@@ -0,0 +1,211 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// structLayout описывает размер и выравнивание структуры из группы параметров
+type structLayout struct {
+	// size размер структуры в байтах при порядке полей order
+	size int64
+	// naive размер структуры в байтах при порядке объявления параметров
+	naive int64
+	// align выравнивание структуры в байтах
+	align int64
+	// order порядок полей: индексы полей в порядке объявления
+	order []int
+	// pointer рекомендует передавать структуру по указателю
+	pointer bool
+}
+
+// String возвращает рекомендацию по способу передачи структуры
+func (l structLayout) String() string {
+	how := "value"
+	if l.pointer {
+		how = "pointer"
+	}
+	if l.size < l.naive {
+		return fmt.Sprintf("pass by %s (%d bytes, align %d; %d bytes in declaration order)", how, l.size, l.align, l.naive)
+	}
+	return fmt.Sprintf("pass by %s (%d bytes, align %d)", how, l.size, l.align)
+}
+
+// layoutOf вычисляет размещение структуры из полей группы.
+// Возвращает false, если размер группы зависит от параметров типа
+func (m *ParamAnalyzer) layoutOf(pass *analysis.Pass, fields []structField) (structLayout, bool) {
+	names := make([]string, 0, len(fields))
+	typs := make([]types.Type, 0, len(fields))
+	for _, field := range fields {
+		t := m.info.TypeOf(field.typ)
+		if t == nil {
+			t = types.Typ[types.Invalid]
+		}
+		if hasTypeParam(t, nil) {
+			return structLayout{}, false
+		}
+		names = append(names, field.name)
+		typs = append(typs, t)
+	}
+	return computeLayout(sizesOf(pass), names, typs, m.pointerThreshold), true
+}
+
+// hasTypeParam проверяет, что тип содержит параметр типа. Размер такого типа
+// неизвестен до инстанцирования, и types.Sizes для него не определен
+func hasTypeParam(t types.Type, seen map[*types.Named]bool) bool {
+	switch t := t.(type) {
+	case *types.TypeParam:
+		return true
+	case *types.Pointer:
+		return hasTypeParam(t.Elem(), seen)
+	case *types.Slice:
+		return hasTypeParam(t.Elem(), seen)
+	case *types.Array:
+		return hasTypeParam(t.Elem(), seen)
+	case *types.Chan:
+		return hasTypeParam(t.Elem(), seen)
+	case *types.Map:
+		return hasTypeParam(t.Key(), seen) || hasTypeParam(t.Elem(), seen)
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			if hasTypeParam(t.Field(i).Type(), seen) {
+				return true
+			}
+		}
+	case *types.Named:
+		if seen[t] {
+			return false
+		}
+		if seen == nil {
+			seen = make(map[*types.Named]bool)
+		}
+		seen[t] = true
+		for i := 0; i < t.TypeArgs().Len(); i++ {
+			if hasTypeParam(t.TypeArgs().At(i), seen) {
+				return true
+			}
+		}
+		return hasTypeParam(t.Underlying(), seen)
+	}
+	return false
+}
+
+// sizesOf возвращает размеры типов целевой платформы анализа
+func sizesOf(pass *analysis.Pass) types.Sizes {
+	if pass.TypesSizes != nil {
+		return pass.TypesSizes
+	}
+	return types.SizesFor("gc", "amd64")
+}
+
+// computeLayout вычисляет размер и выравнивание структуры с полями переданных типов,
+// подбирает порядок полей с минимальным выравнивающим заполнением
+// и сравнивает размер с порогом передачи по указателю.
+// Порядок объявления сохраняется, если перестановка не уменьшает размер
+func computeLayout(sizes types.Sizes, names []string, typs []types.Type, threshold int) structLayout {
+	naive := make([]int, len(typs))
+	for i := range naive {
+		naive[i] = i
+	}
+
+	l := structLayout{order: naive}
+	l.naive, l.align = structSize(sizes, typs, naive)
+	l.size = l.naive
+
+	order := fieldOrder(sizes, names, typs)
+	if size, _ := structSize(sizes, typs, order); size < l.naive {
+		l.size = size
+		l.order = order
+	}
+
+	l.pointer = l.size > int64(threshold)
+	return l
+}
+
+// structSize возвращает размер и выравнивание структуры с полями в указанном порядке
+func structSize(sizes types.Sizes, typs []types.Type, order []int) (int64, int64) {
+	fields := make([]*types.Var, 0, len(order))
+	for i, idx := range order {
+		fields = append(fields, types.NewField(token.NoPos, nil, fmt.Sprintf("f%d", i), typs[idx], false))
+	}
+	st := types.NewStruct(fields, nil)
+	return sizes.Sizeof(st), sizes.Alignof(st)
+}
+
+// fieldUnit группа полей с общим префиксом имени, которые остаются рядом
+type fieldUnit struct {
+	// fields индексы полей группы
+	fields []int
+	// align наибольшее выравнивание полей группы
+	align int64
+	// size суммарный размер полей группы
+	size int64
+}
+
+// fieldOrder возвращает порядок полей, уменьшающий выравнивающее заполнение:
+// поля сортируются по убыванию выравнивания и размера, как в анализаторе fieldalignment.
+// Поля, имена которых начинаются с одного слова (userID, userName), остаются рядом
+func fieldOrder(sizes types.Sizes, names []string, typs []types.Type) []int {
+	var units []*fieldUnit
+	byPrefix := make(map[string]*fieldUnit)
+	for i, t := range typs {
+		prefix := namePrefix(names[i])
+		u, ok := byPrefix[prefix]
+		if !ok {
+			u = &fieldUnit{}
+			units = append(units, u)
+			byPrefix[prefix] = u
+		}
+		u.fields = append(u.fields, i)
+		u.align = max(u.align, sizes.Alignof(t))
+		u.size += sizes.Sizeof(t)
+	}
+
+	less := func(a, b int) bool {
+		alignA, alignB := sizes.Alignof(typs[a]), sizes.Alignof(typs[b])
+		if alignA != alignB {
+			return alignA > alignB
+		}
+		return sizes.Sizeof(typs[a]) > sizes.Sizeof(typs[b])
+	}
+	for _, u := range units {
+		sort.SliceStable(u.fields, func(i, j int) bool { return less(u.fields[i], u.fields[j]) })
+	}
+	sort.SliceStable(units, func(i, j int) bool {
+		if units[i].align != units[j].align {
+			return units[i].align > units[j].align
+		}
+		return units[i].size > units[j].size
+	})
+
+	order := make([]int, 0, len(typs))
+	for _, u := range units {
+		order = append(order, u.fields...)
+	}
+	return order
+}
+
+// namePrefix возвращает первое слово имени в нижнем регистре: userName -> user, HTTPPort -> http
+func namePrefix(name string) string {
+	r := []rune(name)
+	if len(r) == 0 {
+		return ""
+	}
+	end := 1
+	for end < len(r) && !unicode.IsUpper(r[end]) && !unicode.IsDigit(r[end]) && r[end] != '_' {
+		end++
+	}
+	// Аббревиатура в начале имени: HTTPPort -> HTTP
+	if end == 1 && len(r) > 1 && unicode.IsUpper(r[0]) {
+		for end < len(r) && unicode.IsUpper(r[end]) && (end+1 == len(r) || unicode.IsUpper(r[end+1])) {
+			end++
+		}
+	}
+	return strings.ToLower(string(r[:end]))
+}
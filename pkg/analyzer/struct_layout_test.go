@@ -0,0 +1,88 @@
+package analyzer
+
+import (
+	"go/types"
+	"reflect"
+	"testing"
+)
+
+func TestComputeLayout(t *testing.T) {
+	sizes := types.SizesFor("gc", "amd64")
+
+	tests := []struct {
+		name     string
+		names    []string
+		typs     []types.Type
+		expected string
+		order    []int
+	}{
+		{
+			name:     "no padding keeps declaration order",
+			names:    []string{"a", "b"},
+			typs:     []types.Type{types.Typ[types.Int64], types.Typ[types.Int8]},
+			expected: "pass by value (16 bytes, align 8)",
+			order:    []int{0, 1},
+		},
+		{
+			name:     "small fields around a large one are moved",
+			names:    []string{"verbose", "limit", "dryRun"},
+			typs:     []types.Type{types.Typ[types.Bool], types.Typ[types.Int64], types.Typ[types.Bool]},
+			expected: "pass by value (16 bytes, align 8; 24 bytes in declaration order)",
+			order:    []int{1, 0, 2},
+		},
+		{
+			name:     "above threshold",
+			names:    []string{"name", "id"},
+			typs:     []types.Type{types.Typ[types.String], types.Typ[types.Int8]},
+			expected: "pass by pointer (24 bytes, align 8)",
+			order:    []int{0, 1},
+		},
+		{
+			name:     "bytes only",
+			names:    []string{"a", "b", "c"},
+			typs:     []types.Type{types.Typ[types.Int8], types.Typ[types.Bool], types.Typ[types.Uint8]},
+			expected: "pass by value (3 bytes, align 1)",
+			order:    []int{0, 1, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := computeLayout(sizes, tt.names, tt.typs, 16)
+			if got := l.String(); got != tt.expected {
+				t.Errorf("computeLayout() = %q, want %q", got, tt.expected)
+			}
+			if !reflect.DeepEqual(l.order, tt.order) {
+				t.Errorf("computeLayout() order = %v, want %v", l.order, tt.order)
+			}
+		})
+	}
+}
+
+func TestFieldOrderKeepsPrefixGroups(t *testing.T) {
+	sizes := types.SizesFor("gc", "amd64")
+	names := []string{"userName", "enabled", "count", "userAge"}
+	typs := []types.Type{types.Typ[types.String], types.Typ[types.Bool], types.Typ[types.Int64], types.Typ[types.Int32]}
+
+	expected := []int{0, 3, 2, 1}
+	if got := fieldOrder(sizes, names, typs); !reflect.DeepEqual(got, expected) {
+		t.Errorf("fieldOrder() = %v, want %v", got, expected)
+	}
+}
+
+func TestNamePrefix(t *testing.T) {
+	tests := map[string]string{
+		"userName": "user",
+		"user":     "user",
+		"HTTPPort": "http",
+		"ID":       "id",
+		"max_size": "max",
+		"v2":       "v",
+	}
+
+	for name, expected := range tests {
+		if got := namePrefix(name); got != expected {
+			t.Errorf("namePrefix(%q) = %q, want %q", name, got, expected)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package app
+
+import (
+	"example.com/app/service"
+	"example.org/dep"
+	"example.org/lib"
+)
+
+// Тест 1: Цепочка через пакеты модуля
+func Store(key, value, owner string) { // want `make struct with arguments: string, string, string, for call stack: Store -> service.Save -> store.Put, pass by value \(48 bytes, align 8\), place struct in package example.com/app/store`
+	service.Save(key, value, owner)
+}
+
+// Тест 2: Цепочка продолжается в модуле рабочей области, где структуру разместить нельзя
+func Send(key, value, owner string) { // want `make struct with arguments: string, string, string, for call stack: Send -> lib.Send, pass by value \(48 bytes, align 8\)$`
+	lib.Send(key, value, owner)
+}
+
+// Тест 3: Цепочки в модуль-зависимость не продолжаются
+func Handle(key, value, owner string) {
+	dep.Save(key, value, owner)
+}
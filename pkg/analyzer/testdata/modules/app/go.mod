@@ -0,0 +1,7 @@
+module example.com/app
+
+go 1.22
+
+require example.org/dep v0.0.0
+
+replace example.org/dep => ../dep
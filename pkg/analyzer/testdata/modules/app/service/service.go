@@ -0,0 +1,7 @@
+package service
+
+import "example.com/app/store"
+
+func Save(key, value, owner string) {
+	store.Put(key, value, owner)
+}
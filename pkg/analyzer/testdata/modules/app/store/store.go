@@ -0,0 +1,5 @@
+package store
+
+func Put(key, value, owner string) {
+	_ = key + value + owner
+}
@@ -0,0 +1,7 @@
+package dep
+
+import "example.org/dep/store"
+
+func Save(key, value, owner string) {
+	store.Put(key, value, owner)
+}
@@ -0,0 +1,5 @@
+package lib
+
+func Send(key, value, owner string) {
+	_ = key + value + owner
+}
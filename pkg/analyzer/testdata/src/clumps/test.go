@@ -0,0 +1,24 @@
+package clumps
+
+import "context"
+
+// Тест 1: Одна и та же группа параметров в несвязанных сигнатурах
+func Create(ctx context.Context, tenant, user, region string) error              { return nil } // want "make struct with arguments: ctx context.Context, tenant string, user string, region string, repeated in signatures: Create, Delete, Update"
+func Delete(ctx context.Context, tenant, user, region string, force bool) error  { return nil }
+func Update(ctx context.Context, region, tenant, user string, data []byte) error { return nil }
+
+// Тест 2: Совпадают только типы, имена другие (диагностики не должно быть)
+func Move(c context.Context, t, u, r string) error { return nil }
+
+// Тест 3: Группа встречается только в двух сигнатурах (диагностики не должно быть)
+func Start(host string, port int, timeout float64) {}
+func Stop(host string, port int, timeout float64)  {}
+
+// Тест 4: Функции цепочки не учитываются как повторяющаяся группа
+func open(host string, port int, timeout float64) { dial(host, port, timeout) }
+func dial(host string, port int, timeout float64) {} // want "make struct with arguments: float64, int, string, for call stack: open -> dial"
+
+// Тест 5: Группа в трех сигнатурах, каждая пара которых делит еще один параметр
+func Ship(order, sku string, qty int, note string, rush bool) {} // want "make struct with arguments: order string, sku string, qty int, repeated in signatures: Ship, Hold, Pick"
+func Hold(order, sku string, qty int, note string, ref int)   {}
+func Pick(order, sku string, qty int, rush bool, ref int)     {}
@@ -0,0 +1,47 @@
+package compat
+
+import "fmt"
+
+// Тест 1: Экспортированный корень сохраняет старую сигнатуру в виде обертки
+
+// Export sends the record to the remote host.
+func Export(host string, port, retries int, verbose bool) error {
+	if verbose {
+		fmt.Println("export")
+	}
+	return connect(host, port, retries)
+}
+
+func connect(h string, p, r int) error { // want "make struct with arguments: int, int, string, for call stack: Export -> connect"
+	if r > 0 && p > 0 && h != "" {
+		return nil
+	}
+	return nil
+}
+
+func run() error {
+	return Export("localhost", 80, 3, false)
+}
+
+// Тест 2: Имя структуры уже объявлено в пакете (выбирается ServeArgs)
+type ServeParams struct{}
+
+func Serve(host string, port, retries int) {
+	listen(host, port, retries)
+}
+
+func listen(h string, p, r int) { // want "make struct with arguments: int, int, string, for call stack: Serve -> listen"
+	_, _, _ = h, p, r
+}
+
+// Тест 3: Оба имени структуры заняты (выбирается SendParams2)
+type SendParams struct{}
+type SendArgs struct{}
+
+func Send(host string, port, retries int) {
+	deliver(host, port, retries)
+}
+
+func deliver(h string, p, r int) { // want "make struct with arguments: int, int, string, for call stack: Send -> deliver"
+	_, _, _ = h, p, r
+}
@@ -0,0 +1,33 @@
+package constructors
+
+type Server struct{}
+
+// Тест 1: Необязательные параметры почти везде передаются значениями по умолчанию
+func NewServer(addr string, debug, tls bool, retries int) *Server { return nil } // want "use functional options for optional arguments of NewServer: debug, tls, retries \\(literal defaults in 8 of 9 arguments at 3 call sites\\)"
+
+// Тест 2: Логические параметры обычно задаются явно
+func NewClient(addr string, debug, tls bool) *Server { return nil } // want "make options struct for optional arguments of NewClient: debug, tls \\(literal defaults in 1 of 4 arguments at 2 call sites\\)"
+
+// Тест 3: Только один необязательный параметр (диагностики не должно быть)
+func NewCache(name string, size int, verbose bool) *Server { return nil }
+
+// Тест 4: Конструктор уже принимает опции (диагностики не должно быть)
+func NewPool(debug, tls bool, opts ...func()) *Server { return nil }
+
+// Тест 5: Конструктор без вызовов (диагностики не должно быть)
+func NewWorker(debug, tls bool) *Server { return nil }
+
+func main() {
+	NewServer("a", false, false, 0)
+	NewServer("b", false, false, 0)
+	NewServer("c", true, false, 0)
+
+	NewClient("a", true, true)
+	NewClient("b", false, true)
+
+	NewCache("a", 10, false)
+	NewCache("b", 20, true)
+
+	NewPool(false, false)
+	NewPool(false, false)
+}
@@ -0,0 +1,78 @@
+package directives
+
+// Тест 1: Лист цепочки повторяет сигнатуру внешнего API (цепочка не строится)
+func Bind(x, y, z int) {
+	bind(x, y, z)
+}
+
+func bind(x, y, z int) {
+	forward(x, y, z)
+}
+
+//usestruct:ignore mirrors the C library binding
+func forward(x, y, z int) {
+	_, _, _ = x, y, z
+}
+
+// Тест 2: Корень исключен, цепочка начинается со следующей функции
+//
+//nolint:usestruct // public entry point keeps its signature
+func Serve(host string, port, retries int) {
+	listen(host, port, retries)
+}
+
+func listen(host string, port, retries int) {
+	accept(host, port, retries)
+}
+
+func accept(h string, p, r int) { // want "make struct with arguments: int, int, string, for call stack: listen -> accept"
+	_, _, _ = h, p, r
+}
+
+// Тест 3: Цепочка обрывается на вызове с директивой в конце строки
+func Export(a, b, c float64) {
+	convert(a, b, c) //usestruct:ignore
+}
+
+func convert(a, b, c float64) {
+	_, _, _ = a, b, c
+}
+
+// Тест 4: Директива на отдельной строке перед вызовом
+func Draw(a, b, c uint) {
+	//nolint:gocritic,usestruct // protocol callback
+	paint(a, b, c)
+}
+
+func paint(a, b, c uint) {
+	_, _, _ = a, b, c
+}
+
+// Тест 5: Директива подавляет остальные диагностики функции
+func configure(name string, debug, verbose bool) { //nolint:usestruct
+	_, _, _ = name, debug, verbose
+}
+
+func main() {
+	configure("a", true, false)
+	configure("b", false, true)
+}
+
+// Тест 6: Директивы, которые ничего не подавляют
+//
+//usestruct:ignore // want "unused directive //usestruct:ignore: it does not suppress any chain or diagnostic"
+func single(a int) {
+	_ = a
+}
+
+var limit = 3 //nolint:usestruct // want "unused directive //nolint:usestruct: it does not suppress any chain or diagnostic"
+
+// Тест 7: Похожие комментарии не являются директивами
+func Load(a, b, c int8) {
+	//usestruct:ignored
+	store(a, b, c) //nolint:gocritic
+}
+
+func store(a, b, c int8) { // want "make struct with arguments: int8, int8, int8, for call stack: Load -> store"
+	_, _, _ = a, b, c
+}
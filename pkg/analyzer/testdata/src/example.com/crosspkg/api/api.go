@@ -0,0 +1,13 @@
+package api
+
+import "example.com/crosspkg/service"
+
+// Тест 1: Цепочка через два пакета
+func Handle(key, value, owner string) { // want `make struct with arguments: string, string, string, for call stack: Handle -> service.Save -> store.Put, pass by value \(48 bytes, align 8\), place struct in package example.com/crosspkg/store`
+	service.Save(key, value, owner)
+}
+
+// Тест 2: Внутренний пакет недоступен корню цепочки, а пакеты выше листа дали бы цикл
+func Signup(id, name, email string) { // want `make struct with arguments: string, string, string, for call stack: Signup -> service.Register -> db.Write, pass by value \(48 bytes, align 8\), no package for struct is importable by every function of the chain without cycles`
+	service.Register(id, name, email)
+}
@@ -0,0 +1,4 @@
+package model
+
+// ID идентификатор записи
+type ID string
@@ -0,0 +1,5 @@
+package db
+
+func Write(id, name, email string) {
+	_ = id + name + email
+}
@@ -0,0 +1,22 @@
+package service
+
+import (
+	"example.com/crosspkg/model"
+	"example.com/crosspkg/service/internal/db"
+	"example.com/crosspkg/store"
+)
+
+// Тест 1: Структура размещается в пакете листа цепочки
+func Save(key, value, owner string) { // want `make struct with arguments: string, string, string, for call stack: Save -> store.Put, pass by value \(48 bytes, align 8\), place struct in package example.com/crosspkg/store`
+	store.Put(key, value, owner)
+}
+
+// Тест 2: Лист во внутреннем пакете сервиса
+func Register(id, name, email string) { // want `make struct with arguments: string, string, string, for call stack: Register -> db.Write, pass by value \(48 bytes, align 8\), place struct in package example.com/crosspkg/service/internal/db`
+	db.Write(id, name, email)
+}
+
+// Тест 3: Пакет типа поля ниже листа цепочки
+func Fetch(id model.ID, limit, offset int) { // want `make struct with arguments: example.com/crosspkg/model.ID, int, int, for call stack: Fetch -> store.Fetch, pass by value \(32 bytes, align 8\), place struct in package example.com/crosspkg/model`
+	store.Fetch(id, limit, offset)
+}
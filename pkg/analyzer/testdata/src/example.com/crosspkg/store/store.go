@@ -0,0 +1,11 @@
+package store
+
+import "example.com/crosspkg/model"
+
+func Put(key, value, owner string) {
+	_ = key + value + owner
+}
+
+func Fetch(id model.ID, limit, offset int) {
+	_, _, _ = id, limit, offset
+}
@@ -0,0 +1,39 @@
+package fieldorder
+
+// Тест 1: Поля упорядочиваются для уменьшения выравнивания, поля limit* остаются рядом
+func syncAll(level int8, limit int64, mode int8, limitBurst int32) {
+	syncOne(level, limit, mode, limitBurst)
+}
+
+func syncOne(lv int8, l int64, md int8, lb int32) { // want `make struct with arguments: int32, int64, int8, int8, for call stack: syncAll -> syncOne, pass by value \(16 bytes, align 8; 24 bytes in declaration order\)`
+	if lv > md && l > 0 && lb > 0 {
+		return
+	}
+}
+
+// Тест 2: Аргументы с побочными эффектами вычисляются в исходном порядке
+func syncNow() {
+	syncAll(nextLevel(), nextLimit(), nextMode(), nextBurst())
+}
+
+var calls []string
+
+func nextLevel() int8 {
+	calls = append(calls, "level")
+	return 1
+}
+
+func nextLimit() int64 {
+	calls = append(calls, "limit")
+	return 2
+}
+
+func nextMode() int8 {
+	calls = append(calls, "mode")
+	return 3
+}
+
+func nextBurst() int32 {
+	calls = append(calls, "burst")
+	return 4
+}
@@ -0,0 +1,36 @@
+package filter
+
+// Тест 1: Цепочка начинается с экспортированной функции
+func Create(a, b, c int) {
+	create(a, b, c)
+}
+
+func create(a, b, c int) {
+	store(a, b, c)
+}
+
+func store(a, b, c int) { // want "make struct with arguments: int, int, int, for call stack: Create -> create -> store"
+	_, _, _ = a, b, c
+}
+
+// Тест 2: Цепочка начинается с неэкспортированной функции (only_exported)
+func sync(a, b, c string) {
+	push(a, b, c)
+}
+
+func push(a, b, c string) {
+	_, _, _ = a, b, c
+}
+
+// Тест 3: Звено цепочки исключено регулярным выражением
+func Migrate(x, y, z float64) {
+	legacyConvert(x, y, z)
+}
+
+func legacyConvert(x, y, z float64) {
+	apply(x, y, z)
+}
+
+func apply(x, y, z float64) {
+	_, _, _ = x, y, z
+}
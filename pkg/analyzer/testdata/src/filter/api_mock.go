@@ -0,0 +1,10 @@
+package filter
+
+// Тест 4: Файл исключен шаблоном
+func MockCreate(a, b, c int8) {
+	mockStore(a, b, c)
+}
+
+func mockStore(a, b, c int8) {
+	_, _, _ = a, b, c
+}
@@ -0,0 +1,10 @@
+package gen
+
+// Тест 5: Пакет исключен шаблоном
+func Encode(a, b, c int) {
+	encode(a, b, c)
+}
+
+func encode(a, b, c int) {
+	_, _, _ = a, b, c
+}
@@ -0,0 +1,42 @@
+package fixedsig
+
+// Тест 1: Лист цепочки повторяет сигнатуру обратного вызова протокола
+func Handle(id, size, flags int) {
+	process(id, size, flags)
+}
+
+func process(id, size, flags int) { // want "make struct with arguments: int, int, int, for call stack: Handle -> process -> onPacket"
+	onPacket(id, size, flags)
+}
+
+// onPacket вызывается библиотекой протокола
+//
+//usestruct:fixed-signature
+func onPacket(id, size, flags int) {
+	_, _, _ = id, size, flags
+}
+
+// Тест 2: Корень цепочки привязан к внешнему API
+//
+//usestruct:fixed-signature C binding
+func Callback(x, y, z float64) {
+	decode(x, y, z)
+}
+
+func decode(x, y, z float64) {
+	apply(x, y, z)
+}
+
+func apply(x, y, z float64) { // want "make struct with arguments: float64, float64, float64, for call stack: Callback -> decode -> apply"
+	_, _, _ = x, y, z
+}
+
+// Тест 3: После исключения остается одна функция (без исправления)
+func Send(a, b, c string) { // want "make struct with arguments: string, string, string, for call stack: Send -> write"
+	write(a, b, c)
+}
+
+//usestruct:fixed-signature
+func write(a, b, c string) {
+	_, _, _ = a, b, c
+}
@@ -0,0 +1,44 @@
+package flags
+
+import (
+	"io"
+	"time"
+)
+
+type Mode int
+
+const (
+	ModeFast Mode = iota
+	ModeSafe
+)
+
+// Тест 1: Логические флаги передаются через цепочку вместе с другим параметром (сообщается и об обычной цепочке)
+func render(w io.Writer, header, footer, border bool) { draw(w, header, footer, border) } // want "make flags struct for arguments: bool, bool, bool, for call stack: render -> draw -> paint; call sites: render\\(nil, true, false, true\\)"
+func draw(w io.Writer, header, footer, border bool)   { paint(w, header, footer, border) }
+func paint(w io.Writer, header, footer, border bool)  {} // want "make struct with arguments: bool, bool, bool, io.Writer, for call stack: render -> draw -> paint"
+
+// Тест 2: Логический флаг и небольшое перечисление в одной функции
+func configure(name string, debug bool, mode Mode) {} // want "make flags struct for arguments of configure: debug bool, mode flags.Mode; call sites: configure\\(\"a\", true, ModeSafe\\), configure\\(\"b\", false, ModeFast\\)"
+
+// Тест 3: Только один флаг (диагностики не должно быть)
+func toggle(name string, on bool) {}
+
+// Тест 4: Обычный int не является перечислением (диагностики не должно быть)
+func resize(w, h int, keep bool) {}
+
+// Тест 5: Единица измерения с константами не является перечислением (диагностики не должно быть)
+func retry(name string, timeout, backoff time.Duration) {}
+
+// Тест 6: Цепочка из одних флагов (только структура флагов)
+func apply(dry, force, verbose bool)  { commit(dry, force, verbose) } // want "make flags struct for arguments: bool, bool, bool, for call stack: apply -> commit"
+func commit(dry, force, verbose bool) {}
+
+func main() {
+	render(nil, true, false, true)
+	configure("a", true, ModeSafe)
+	configure("b", false, ModeFast)
+	configure("c", false, ModeFast)
+	toggle("x", true)
+	resize(1, 2, true)
+	retry("x", time.Second, time.Millisecond)
+}
@@ -0,0 +1,19 @@
+package generated
+
+// Тест 1: Звено цепочки в сгенерированном файле исключено (цепочки нет)
+func Run(a, b, c int) {
+	genStep(a, b, c)
+}
+
+func handLeaf(a, b, c int) {
+	_, _, _ = a, b, c
+}
+
+// Тест 2: Корень вызывается из сгенерированного файла (только объект-метод)
+func Start(x, y, z float64) {
+	finish(x, y, z)
+}
+
+func finish(x, y, z float64) { // want "make struct with arguments: float64, float64, float64, for call stack: Start -> finish"
+	_, _, _ = x, y, z
+}
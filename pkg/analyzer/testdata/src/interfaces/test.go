@@ -0,0 +1,134 @@
+package interfaces
+
+// Store сохраняет записи
+type Store interface {
+	save(key, value string, ttl int) error
+}
+
+type memStore struct{ data map[string]string }
+
+type mockStore struct{ calls int }
+
+func (s *mockStore) save(key, value string, ttl int) error {
+	s.calls++
+	if ttl > 0 && key != value {
+		return nil
+	}
+	return nil
+}
+
+func flush(st Store) error {
+	return st.save("a", "b", 0)
+}
+
+// Тест 1: Метод цепочки реализует интерфейс пакета
+func process(s *memStore, key, value string, ttl int) error {
+	return s.save(key, value, ttl)
+}
+
+func (s *memStore) save(k, v string, t int) error { // want "make struct with arguments: int, string, string, for call stack: process -> memStore.save"
+	if t > 0 {
+		s.data[k] = v
+	}
+	return nil
+}
+
+// Тест 2: Экспортированный метод реализует неэкспортированный интерфейс пакета
+type cache interface {
+	Put(id, size int, name string)
+}
+
+type memCache struct{ data map[string]int }
+
+func put(c *memCache, id, size int, name string) {
+	c.Put(id, size, name)
+}
+
+func (c *memCache) Put(i, s int, n string) { // want "make struct with arguments: int, int, string, for call stack: put -> memCache.Put"
+	if i > 0 {
+		c.data[n] = s
+	}
+}
+
+func fill(c cache) {
+	c.Put(1, 2, "a")
+}
+
+// Тест 3: Метод с получателем-значением, интерфейс реализует только указатель на тип
+type sink interface {
+	write(x, y float64, label string)
+	reset()
+}
+
+type fileSink struct{ lines []string }
+
+var _ sink = &fileSink{}
+
+func (f *fileSink) reset() {
+	f.lines = nil
+}
+
+func emit(f fileSink, x, y float64, label string) {
+	f.write(x, y, label)
+}
+
+func (f fileSink) write(a, b float64, l string) { // want "make struct with arguments: float64, float64, string, for call stack: emit -> fileSink.write"
+	if a > b {
+		_ = l
+	}
+}
+
+// Тест 4: Экспортированный интерфейс входит в API пакета (без исправления)
+type Queue interface {
+	Push(id, size int, tag string)
+}
+
+type memQueue struct{ items map[string]int }
+
+func enqueue(q *memQueue, id, size int, tag string) {
+	q.Push(id, size, tag)
+}
+
+func (q *memQueue) Push(i, s int, t string) { // want "make struct with arguments: int, int, string, for call stack: enqueue -> memQueue.Push"
+	if i > 0 {
+		q.items[t] = s
+	}
+}
+
+// Тест 5: Аргументы вызова через интерфейс вычисляются в исходном порядке
+type meter interface {
+	record(flag int8, total int64, kind int8)
+}
+
+type memMeter struct{ sum int64 }
+
+func measure(m *memMeter, flag int8, total int64, kind int8) {
+	m.record(flag, total, kind)
+}
+
+func (m *memMeter) record(f int8, t int64, k int8) { // want "make struct with arguments: int64, int8, int8, for call stack: measure -> memMeter.record"
+	if f > k {
+		m.sum += t
+	}
+}
+
+func observe(m meter) {
+	m.record(nextFlag(), nextTotal(), nextKind())
+}
+
+var order []string
+
+func nextFlag() int8 {
+	order = append(order, "flag")
+	return 1
+}
+
+func nextTotal() int64 {
+	order = append(order, "total")
+	return 2
+}
+
+func nextKind() int8 {
+	order = append(order, "kind")
+	return 3
+}
@@ -0,0 +1,28 @@
+package layout
+
+// Тест 1: Большая группа передается по указателю
+func main() {
+	Render("title", "header", "body", "footer", "style", "script")
+}
+
+func Render(title, header, body, footer, style, script string) {
+	draw(title, header, body, footer, style, script)
+}
+
+func draw(t, h, b, f, st, sc string) { // want `make struct with arguments: string, string, string, string, string, string, for call stack: Render -> draw, pass by pointer \(96 bytes, align 8\)`
+	_ = t + h + b + f + st + sc
+}
+
+// Тест 2: Небольшая группа передается по значению (без исправления)
+func Load(a, b int8, c int16)  { Parse(a, b, c) }
+func Parse(a, b int8, c int16) { store(a, b, c) }
+func store(a, b int8, c int16) {} // want `make struct with arguments: int16, int8, int8, for call stack: Load -> Parse -> store, pass by value \(4 bytes, align 2\)`
+
+// Тест 3: Обобщенная цепочка: размер группы неизвестен, рекомендации по размещению нет
+func outer[T any](a, b, c T) {
+	inner(a, b, c)
+}
+
+func inner[T any](x, y, z T) { // want `make struct with arguments: T, T, T, for call stack: outer -> inner$`
+	_, _, _ = x, y, z
+}
@@ -0,0 +1,23 @@
+package longparams
+
+type Server struct{}
+
+// Тест 1: Неэкспортируемая функция с ограничением по умолчанию (5)
+func short(a, b, c, d, e int)   {}
+func long(a, b, c, d, e, f int) {} // want "make struct for arguments of long: 6 parameters exceed the limit of 5 for functions"
+
+// Тест 2: Экспортируемая функция (ограничение 4)
+func Export(a, b, c, d int)        {}
+func ExportMore(a, b, c, d, e int) {} // want "make struct for arguments of ExportMore: 5 parameters exceed the limit of 4 for exported functions"
+
+// Тест 3: Метод (ограничение 3)
+func (s *Server) Listen(host string, port int, tls bool)            {}
+func (s *Server) Serve(host string, port int, tls bool, debug bool) {} // want "make struct for arguments of Serve: 4 parameters exceed the limit of 3 for methods"
+
+// Тест 4: Конструктор (ограничение 7)
+func NewServer(a, b, c, d, e, f, g int) *Server    { return nil }
+func NewClient(a, b, c, d, e, f, g, h int) *Server { return nil } // want "make struct for arguments of NewClient: 8 parameters exceed the limit of 7 for constructors"
+func Newsletter(a, b, c, d, e int)                 {}             // want "make struct for arguments of Newsletter: 5 parameters exceed the limit of 4 for exported functions"
+
+// Тест 5: Безымянные параметры тоже учитываются
+func unnamed(int, string, bool, float64, error, []byte) {} // want "make struct for arguments of unnamed: 6 parameters exceed the limit of 5 for functions"
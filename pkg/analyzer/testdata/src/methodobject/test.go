@@ -0,0 +1,44 @@
+package methodobject
+
+import "fmt"
+
+// Export Тест 1: Цепочка свободных функций одного файла (с исправлением)
+func Export(host string, port, retries int, verbose bool) error {
+	if verbose {
+		fmt.Println("export")
+	}
+	return connect(host, port, retries)
+}
+
+func connect(host string, port, retries int) error {
+	addr := fmt.Sprintf("%s:%d", host, port)
+	return dial(host, port, retries, addr)
+}
+
+func dial(h string, p, r int, addr string) error { // want "make struct with arguments: int, int, string, for call stack: Export -> connect -> dial"
+	if r > 0 && p > 0 && h+addr != "" {
+		return nil
+	}
+	return nil
+}
+
+// Тест 2: Промежуточная функция экспортирована (без исправления)
+func Load(a, b, c int)  { Parse(a, b, c) }
+func Parse(a, b, c int) { store(a, b, c) }
+func store(a, b, c int) {} // want "make struct with arguments: int, int, int, for call stack: Load -> Parse -> store"
+
+// Тест 3: Функция цепочки используется как значение (без исправления)
+var handler = apply
+
+func prepare(a, b, c float64) { apply(a, b, c) }
+func apply(a, b, c float64)   {} // want "make struct with arguments: float64, float64, float64, for call stack: prepare -> apply"
+
+// Тест 4: Обобщенная корневая функция (без исправления)
+func collect[T any](n, m, k int, v T) {
+	merge(n, m, k)
+	_ = v
+}
+
+func merge(x, y, z int) { // want "make struct with arguments: int, int, int, for call stack: collect -> merge"
+	_, _, _ = x, y, z
+}
@@ -0,0 +1,14 @@
+package names
+
+// Тест 4: Поле получателя с именем параметра уже есть (выбирается поле title2)
+type Window struct {
+	title string
+}
+
+func (w *Window) Open(width, height int, title string) {
+	w.resize(width, height, title)
+}
+
+func (w *Window) resize(x, y int, title string) { // want "move arguments to fields of Window: int, int, string, for call stack: Window.Open -> Window.resize"
+	w.title = title + w.title[x:y]
+}
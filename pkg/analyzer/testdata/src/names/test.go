@@ -0,0 +1,42 @@
+package names
+
+import drawParams "strings"
+
+// Тест 1: Имя структуры совпадает с именем импорта файла (выбирается drawArgs)
+func draw(x, y int, label string) {
+	paint(x, y, label)
+}
+
+func paint(x, y int, label string) { // want "make struct with arguments: int, int, string, for call stack: draw -> paint"
+	_, _, _ = x, y, label
+}
+
+func repeat(s string) string { return drawParams.Repeat(s, 2) }
+
+// Тест 2: Имя структуры перекрыто переменной в месте вызова корня (выбирается renderArgs)
+func Page() {
+	renderParams := 1
+	render(renderParams, 2, "page")
+}
+
+func render(w, h int, title string) {
+	layout(w, h, title)
+}
+
+func layout(w, h int, title string) { // want "make struct with arguments: int, int, string, for call stack: render -> layout"
+	_, _, _ = w, h, title
+}
+
+// Тест 3: Поле объекта-метода совпадает с именем метода (выбирается поле validate2)
+func Verify(validate string, a, b int) error {
+	return run(validate, a, b)
+}
+
+func run(v string, a, b int) error {
+	return validate(v, a, b)
+}
+
+func validate(c string, a, b int) error { // want "make struct with arguments: int, int, string, for call stack: Verify -> run -> validate"
+	_, _, _ = c, a, b
+	return nil
+}
@@ -0,0 +1,19 @@
+package domain
+
+// Тест 1: Цепочка из трех параметров
+func Charge(amount, fee, tax int) {
+	settle(amount, fee, tax)
+}
+
+func settle(amount, fee, tax int) { // want "make struct with arguments: int, int, int, for call stack: Charge -> settle"
+	_, _, _ = amount, fee, tax
+}
+
+// Тест 2: Переопределение для overrides/... уменьшает размер группы до двух параметров
+func Refund(order, reason string) {
+	revert(order, reason)
+}
+
+func revert(order, reason string) { // want "make struct with arguments: string, string, for call stack: Refund -> revert"
+	_, _ = order, reason
+}
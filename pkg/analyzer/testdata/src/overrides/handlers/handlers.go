@@ -0,0 +1,45 @@
+package handlers
+
+// Тест 1: Цепочка короче переопределенной длины
+func Get(id, page, size, sort int) {
+	list(id, page, size, sort)
+}
+
+func list(id, page, size, sort int) {
+	_, _, _, _ = id, page, size, sort
+}
+
+// Тест 2: Группа меньше переопределенного размера
+func Post(user, body, token string) {
+	validate(user, body, token)
+}
+
+func validate(name, data, key string) {
+	save(name, data, key)
+}
+
+func save(login, payload, secret string) {
+	_, _, _ = login, payload, secret
+}
+
+// Тест 3: Цепочка проходит оба переопределенных порога
+func Put(x, y, w, h float64) {
+	resize(x, y, w, h)
+}
+
+func resize(x, y, w, h float64) {
+	draw(x, y, w, h)
+}
+
+func draw(x, y, w, h float64) { // want "make struct with arguments: float64, float64, float64, float64, for call stack: Put -> resize -> draw"
+	_, _, _, _ = x, y, w, h
+}
+
+// Тест 4: Цепочка флагов короче переопределенной длины (флаги каждой функции отдельно)
+func Delete(force, recursive, dry, quiet bool) { // want "make flags struct for arguments of Delete: force bool, recursive bool, dry bool, quiet bool"
+	remove(force, recursive, dry, quiet)
+}
+
+func remove(force, recursive, dry, quiet bool) { // want "make flags struct for arguments of remove: force bool, recursive bool, dry bool, quiet bool"
+	_, _, _, _ = force, recursive, dry, quiet
+}
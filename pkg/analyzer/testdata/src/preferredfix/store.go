@@ -0,0 +1,5 @@
+package preferredfix
+
+func store(name string, limit, burst int) { // want "make struct with arguments: int, int, string, for call stack: load -> store"
+	_, _, _ = name, limit, burst
+}
@@ -0,0 +1,18 @@
+package preferredfix
+
+// Тест 1: Можно ввести и структуру параметров, и объект-метод (предлагается объект-метод)
+func export(host string, port, retries int) error {
+	return connect(host, port, retries)
+}
+
+func connect(host string, port, retries int) error { // want "make struct with arguments: int, int, string, for call stack: export -> connect"
+	if retries > 0 && port > 0 && host != "" {
+		return nil
+	}
+	return nil
+}
+
+// Тест 2: Функции цепочки в разных файлах, объект-метод ввести нельзя (предлагается структура параметров)
+func load(name string, limit, burst int) {
+	store(name, limit, burst)
+}
@@ -0,0 +1,43 @@
+package receiver
+
+import "fmt"
+
+// Тест 1: Цепочка неэкспортируемых методов одного типа (с исправлением)
+type Renderer struct {
+	name string
+}
+
+func (r *Renderer) Render(width, height int, title string) {
+	r.layout(width, height, title)
+}
+
+func (r *Renderer) layout(w, h int, t string) {
+	fmt.Println(r.name, w, h)
+	r.draw(w, h, t)
+}
+
+func (r *Renderer) draw(x, y int, caption string) { // want "move arguments to fields of Renderer: int, int, string, for call stack: Renderer.Render -> Renderer.layout -> Renderer.draw"
+	r.name = caption + r.name[x:y]
+}
+
+// Тест 2: Промежуточный метод экспортирован (без исправления)
+type Exporter struct{}
+
+func (e Exporter) Run(a, b, c int)    { e.Export(a, b, c) }
+func (e Exporter) Export(a, b, c int) { e.write(a, b, c) }
+func (e Exporter) write(a, b, c int)  {} // want "move arguments to fields of Exporter: int, int, int, for call stack: Exporter.Run -> Exporter.Export -> Exporter.write"
+
+// Тест 3: Методы разных типов (обычная диагностика)
+type Reader struct{}
+type Writer struct{}
+
+func (r Reader) Read(a, b, c int)  { w := Writer{}; w.Write(a, b, c) }
+func (w Writer) Write(a, b, c int) {} // want "make struct with arguments: int, int, int, for call stack: Reader.Read -> Writer.Write"
+
+// Тест 4: Метод цепочки реализует интерфейс пакета (без исправления)
+type sink interface{ put(a, b, c float64) }
+
+type Meter struct{}
+
+func (m *Meter) Record(a, b, c float64) { m.put(a, b, c) }
+func (m *Meter) put(a, b, c float64)    {} // want "move arguments to fields of Meter: float64, float64, float64, for call stack: Meter.Record -> Meter.put"
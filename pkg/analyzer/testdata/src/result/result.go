@@ -0,0 +1,31 @@
+package result
+
+// Тест 1: Цепочка через метод, пропущенный параметр корня входит в группу
+type Client struct{}
+
+func Open(host string, port int, secure bool, _ int) { // want "chain Open -> Client.dial: host string, port int, secure bool, _ int"
+	var c Client
+	c.dial(host, port, secure, 0)
+}
+
+func (c Client) dial(addr string, p int, tls bool, retries int) {
+	_, _, _, _ = addr, p, tls, retries
+}
+
+// Тест 2: Функции с двумя параметрами не образуют цепочку
+func Close(host string, port int) {
+	closeConn(host, port)
+}
+
+func closeConn(host string, port int) {
+	_, _ = host, port
+}
+
+// Тест 3: Цепочка из одних флагов, о ней сообщается в корне
+func Render(header, footer, border bool) { // want "chain Render -> paint: header bool, footer bool, border bool"
+	paint(header, footer, border)
+}
+
+func paint(h, f, b bool) {
+	_, _, _ = h, f, b
+}
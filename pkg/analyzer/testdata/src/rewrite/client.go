@@ -0,0 +1,8 @@
+package rewrite
+
+// Тест 2: Поля получателя объявлены в файле без нужного импорта
+type Client struct {
+	name string
+	// retries количество повторов
+	retries int
+}
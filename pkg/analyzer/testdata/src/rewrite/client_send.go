@@ -0,0 +1,15 @@
+package rewrite
+
+import "time"
+
+func (c *Client) Send(
+	body string, // тело запроса
+	deadline time.Time,
+	at time.Time,
+) {
+	c.send(body, deadline, at)
+}
+
+func (c *Client) send(b string, t, d time.Time) { // want "move arguments to fields of Client: string, time.Time, time.Time, for call stack: Client.Send -> Client.send"
+	c.name, _, _ = b, t, d
+}
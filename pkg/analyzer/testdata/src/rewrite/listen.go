@@ -0,0 +1,12 @@
+package rewrite
+
+import (
+	"fmt"
+	"time"
+)
+
+var _ = fmt.Sprint
+
+func listen(addr string, p int, t time.Time, name string) { // want `make struct with arguments: int, string, string, time.Time, for call stack: Serve -> listen, pass by value \(64 bytes, align 8\)`
+	_, _, _, _ = addr, p, t, name
+}
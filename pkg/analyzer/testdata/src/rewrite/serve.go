@@ -0,0 +1,25 @@
+package rewrite
+
+import (
+	"fmt"
+	"time"
+)
+
+// Тест 1: Комментарии параметров переходят в поля структуры, а импорт,
+// нужный только удаленному параметру, удаляется из файла листа
+func main() {
+	fmt.Println("start")
+	Serve("localhost", 8080, time.Now(), "api")
+}
+
+// Serve запускает сервер
+func Serve(
+	// host адрес для прослушивания
+	host string,
+	port int, // порт
+
+	deadline time.Time, // крайний срок запроса
+	name string,
+) {
+	listen(host, port, deadline, name)
+}
@@ -16,7 +16,7 @@ type Processor struct{}
 
 func (p *Processor) Start(x, y, z int)   { p.Process(x, y, z) }
 func (p *Processor) Process(a, b, c int) { p.Finish(a, b, c) }
-func (p *Processor) Finish(i, j, k int)  {} // want "make struct with arguments: int, int, int, for call stack: Processor.Start -> Processor.Process -> Processor.Finish"
+func (p *Processor) Finish(i, j, k int)  {} // want "move arguments to fields of Processor: int, int, int, for call stack: Processor.Start -> Processor.Process -> Processor.Finish"
 
 // Тест 3: Вложенные цепочки (должна быть выбрана только самая длинная)
 func alpha(x, y, z int)   { beta(x, y, z) }
@@ -0,0 +1,131 @@
+package chains
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"slices"
+	"strings"
+
+	"github.com/Truenya/usestruct/pkg/analyzer"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+// LoadMode режим загрузки пакетов для Find. Цепочки продолжаются в зависимости
+// по фактам, поэтому зависимости загружаются с синтаксисом и типами, а модули
+// нужны, чтобы не продолжать цепочки в модули-зависимости
+const LoadMode = packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles | packages.NeedImports |
+	packages.NeedDeps | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedTypesSizes |
+	packages.NeedModule
+
+// Chain цепочка вызовов, через все функции которой передается группа параметров
+type Chain struct {
+	// Package путь пакета, в котором найдена цепочка (пакет ее корня)
+	Package string
+	// Hops функции цепочки от корня к последней функции
+	Hops []Hop
+	// Group группа параметров, передаваемых через всю цепочку
+	Group Group
+	// Pos позиция, в которой анализатор сообщает о цепочке
+	Pos token.Position
+}
+
+// Hop функция цепочки
+type Hop struct {
+	// Func имя функции в пакете: Func или Type.Method
+	Func string
+	// Package путь пакета функции
+	Package string
+	// Pos позиция объявления функции. Для функций других пакетов позиция не заполняется
+	Pos token.Position
+}
+
+// Group группа параметров цепочки
+type Group struct {
+	// Params параметры в порядке объявления в корневой функции
+	Params []Param
+}
+
+// Param параметр группы
+type Param struct {
+	// Name имя параметра в корневой функции или пустая строка для безымянного параметра
+	Name string
+	// Type тип параметра с полными путями пакетов
+	Type string
+}
+
+// String возвращает цепочку в формате сообщений анализатора: Root -> Func -> Leaf
+func (c Chain) String() string {
+	names := make([]string, 0, len(c.Hops))
+	for _, hop := range c.Hops {
+		names = append(names, hop.Func)
+	}
+	return strings.Join(names, " -> ")
+}
+
+// Find находит цепочки в пакетах, загруженных с режимом LoadMode. Используются те же
+// поиск и фильтрация цепочек, что и в анализаторе: возвращаются цепочки, о которых он
+// сообщил бы с настройками opts (см. analyzer.DefaultOptions). Цепочки упорядочены
+// по позиции, дубли из тестовых вариантов пакетов отброшены
+func Find(pkgs []*packages.Package, opts analyzer.Options) ([]Chain, error) {
+	a := analyzer.AnalyzerWithOptions(opts)
+	graph, err := checker.Analyze([]*analysis.Analyzer{a}, pkgs, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var chains []Chain
+	var errs []error
+	seen := make(map[string]bool)
+	for _, act := range graph.Roots {
+		if act.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", act.Package.PkgPath, act.Err))
+			continue
+		}
+		result, ok := act.Result.(*analyzer.Result)
+		if !ok {
+			continue
+		}
+		for _, c := range result.Chains {
+			chain := convert(act.Package, c)
+			key := chain.Pos.String() + "@" + chain.String()
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			chains = append(chains, chain)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	slices.SortFunc(chains, func(a, b Chain) int {
+		if c := strings.Compare(a.Pos.Filename, b.Pos.Filename); c != 0 {
+			return c
+		}
+		return a.Pos.Offset - b.Pos.Offset
+	})
+	return chains, nil
+}
+
+// convert преобразует цепочку результата анализатора
+func convert(pkg *packages.Package, c analyzer.Chain) Chain {
+	chain := Chain{
+		Package: pkg.PkgPath,
+		Pos:     pkg.Fset.Position(c.Pos),
+	}
+	for _, fn := range c.Funcs {
+		hop := Hop{Func: fn.Name, Package: fn.PkgPath}
+		if fn.Decl != nil {
+			hop.Pos = pkg.Fset.Position(fn.Decl.Pos())
+		}
+		chain.Hops = append(chain.Hops, hop)
+	}
+	for _, p := range c.Params {
+		chain.Group.Params = append(chain.Group.Params, Param{Name: p.Name, Type: p.Type.String()})
+	}
+	return chain
+}
@@ -0,0 +1,64 @@
+package chains
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Truenya/usestruct/pkg/analyzer"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestFind(t *testing.T) {
+	pkgs, err := packages.Load(&packages.Config{Mode: LoadMode}, "./testdata/shop")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		t.Fatal("failed to load testdata")
+	}
+
+	chains, err := Find(pkgs, analyzer.DefaultOptions())
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+
+	const pkgPath = "github.com/Truenya/usestruct/pkg/chains/testdata/shop"
+	tests := []struct {
+		chain string
+		group []Param
+		line  int
+	}{
+		{
+			chain: "Checkout -> pay -> charge",
+			group: []Param{{Name: "cart", Type: "string"}, {Name: "total", Type: "float64"}, {Name: "discount", Type: "float64"}},
+			line:  12,
+		},
+		{
+			chain: "Store.Ship -> Store.pack",
+			group: []Param{{Name: "order", Type: "string"}, {Name: "address", Type: "string"}, {Name: "courier", Type: "string"}},
+			line:  23,
+		},
+	}
+	if len(chains) != len(tests) {
+		t.Fatalf("Find() = %v, want %d chains", chains, len(tests))
+	}
+
+	for i, tt := range tests {
+		chain := chains[i]
+		if chain.String() != tt.chain {
+			t.Errorf("chain %d = %s, want %s", i, chain, tt.chain)
+		}
+		if !reflect.DeepEqual(chain.Group.Params, tt.group) {
+			t.Errorf("chain %s: group = %v, want %v", chain, chain.Group.Params, tt.group)
+		}
+		if chain.Package != pkgPath || filepath.Base(chain.Pos.Filename) != "shop.go" || chain.Pos.Line != tt.line {
+			t.Errorf("chain %s: reported in %s at %s, want line %d", chain, chain.Package, chain.Pos, tt.line)
+		}
+		for _, hop := range chain.Hops {
+			if hop.Package != pkgPath || !hop.Pos.IsValid() {
+				t.Errorf("chain %s: hop %s in %s at %s", chain, hop.Func, hop.Package, hop.Pos)
+			}
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package shop
+
+// Тест 1: Цепочка свободных функций
+func Checkout(cart string, total, discount float64) {
+	pay(cart, total, discount)
+}
+
+func pay(cart string, total, discount float64) {
+	charge(cart, total, discount)
+}
+
+func charge(id string, sum, off float64) {
+	_, _, _ = id, sum, off
+}
+
+// Тест 2: Цепочка методов одного типа
+type Store struct{}
+
+func (s *Store) Ship(order, address, courier string) {
+	s.pack(order, address, courier)
+}
+
+func (s *Store) pack(order, address, courier string) {
+	_, _, _ = order, address, courier
+}
@@ -0,0 +1,180 @@
+package usestruct
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// validateSettings checks raw settings against the fields of the struct type t: every key must
+// name a field by its json tag, values must have the field type, integers must respect the min
+// tag and strings the enum tag. Errors name the offending key by its path, e.g. settings.max_params,
+// or by the key alone if path is empty
+func validateSettings(settings map[string]any, t reflect.Type, path string) error {
+	fields := make(map[string]reflect.StructField, t.NumField())
+	keys := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key := jsonKey(field)
+		if key == "" {
+			continue
+		}
+		fields[key] = field
+		keys = append(keys, key)
+	}
+
+	names := make([]string, 0, len(settings))
+	for key := range settings {
+		names = append(names, key)
+	}
+	slices.Sort(names)
+
+	for _, key := range names {
+		field, ok := fields[key]
+		if !ok {
+			if suggestion, ok := closestKey(key, keys); ok {
+				return fmt.Errorf("%s: unknown key, did you mean %s?", keyPath(path, key), suggestion)
+			}
+			return fmt.Errorf("%s: unknown key", keyPath(path, key))
+		}
+		if err := validateValue(settings[key], field, keyPath(path, key)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateValue checks a single setting value against the field it is decoded into
+func validateValue(value any, field reflect.StructField, path string) error {
+	switch field.Type.Kind() {
+	case reflect.Int:
+		n, ok := integer(value)
+		if !ok {
+			return typeError(path, "integer", value)
+		}
+		if limit, ok := field.Tag.Lookup("min"); ok {
+			if low, _ := strconv.ParseInt(limit, 10, 64); n < low {
+				return fmt.Errorf("%s: must be at least %d, got %d", path, low, n)
+			}
+		}
+	case reflect.Bool:
+		if _, ok := value.(bool); !ok {
+			return typeError(path, "boolean", value)
+		}
+	case reflect.String:
+		s, ok := value.(string)
+		if !ok {
+			return typeError(path, "string", value)
+		}
+		if enum, ok := field.Tag.Lookup("enum"); ok && !slices.Contains(strings.Split(enum, ","), s) {
+			return fmt.Errorf("%s: must be one of %s, got %q", path, strings.ReplaceAll(enum, ",", ", "), s)
+		}
+	case reflect.Slice:
+		items, ok := sliceItems(value)
+		if !ok {
+			return typeError(path, "list", value)
+		}
+		elem := reflect.StructField{Type: field.Type.Elem(), Tag: field.Tag}
+		for i, item := range items {
+			if err := validateValue(item, elem, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		m, ok := value.(map[string]any)
+		if !ok {
+			return typeError(path, "map", value)
+		}
+		return validateSettings(m, field.Type, path)
+	}
+	return nil
+}
+
+// keyPath appends a key to the path of the enclosing map
+func keyPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// jsonKey returns the settings key of a struct field, or "" if the field is not decoded
+func jsonKey(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" || !field.IsExported() {
+		return ""
+	}
+	return name
+}
+
+// integer converts the numeric types produced by YAML and JSON decoders to an integer
+func integer(value any) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int64:
+		return v, true
+	case int32:
+		return int64(v), true
+	case uint64:
+		return int64(v), v <= math.MaxInt64
+	case float64:
+		return int64(v), v == math.Trunc(v) && math.Abs(v) < 1<<53
+	}
+	return 0, false
+}
+
+// sliceItems returns the elements of a list value
+func sliceItems(value any) ([]any, bool) {
+	switch v := value.(type) {
+	case []any:
+		return v, true
+	case []string:
+		items := make([]any, 0, len(v))
+		for _, s := range v {
+			items = append(items, s)
+		}
+		return items, true
+	}
+	return nil, false
+}
+
+// typeError describes a value of the wrong type
+func typeError(path, want string, value any) error {
+	return fmt.Errorf("%s: expected %s, got %T (%v)", path, want, value, value)
+}
+
+// closestKey returns the known key closest to an unknown one if it looks like a typo
+func closestKey(key string, keys []string) (string, bool) {
+	best, bestDist := "", len(key)/3+1
+	for _, k := range keys {
+		if d := editDistance(key, k); d < bestDist {
+			best, bestDist = k, d
+		}
+	}
+	return best, best != ""
+}
+
+// editDistance returns the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
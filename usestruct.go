@@ -3,6 +3,8 @@ package usestruct
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"regexp"
 
 	"github.com/Truenya/usestruct/pkg/analyzer"
 	"github.com/golangci/plugin-module-register/register"
@@ -16,64 +18,252 @@ func init() {
 // Config holds the configuration for the usestruct analyzer
 type Config struct {
 	// MinRequiredParams defines the minimum number of parameters required for analysis
-	MinRequiredParams int `json:"min_required_params"`
+	MinRequiredParams int `json:"min_required_params" min:"1"`
 	// MaxRecursionDepth defines the maximum recursion depth when analyzing call chains
-	MaxRecursionDepth int `json:"max_recursion_depth"`
+	MaxRecursionDepth int `json:"max_recursion_depth" min:"1"`
+	// MinGroupSize defines the minimum number of parameters forwarded through the whole chain
+	MinGroupSize int `json:"min_group_size" min:"2"`
+	// MinChainLength defines the minimum number of functions in a reported chain
+	MinChainLength int `json:"min_chain_length" min:"2"`
+	// MinClumpSize defines the minimum size of a parameter group repeated across unrelated signatures
+	MinClumpSize int `json:"min_clump_size" min:"2"`
+	// MinClumpOccurrences defines how many signatures must share a parameter group to report it
+	MinClumpOccurrences int `json:"min_clump_occurrences" min:"2"`
+	// MinOptionalParams defines how many optional-looking constructor parameters trigger the options suggestion
+	MinOptionalParams int `json:"min_optional_params" min:"1"`
+	// MinFlagParams defines how many bool (or small-enum integer) parameters trigger the flags struct suggestion
+	MinFlagParams int `json:"min_flag_params" min:"1"`
+	// PointerSizeThreshold defines the struct size in bytes above which the suggested struct is passed by pointer
+	PointerSizeThreshold int `json:"pointer_size_threshold" min:"0"`
+	// CompatWrappers keeps the old signature of exported chain roots as deprecated wrappers in suggested fixes
+	CompatWrappers bool `json:"compat_wrappers"`
+	// PreferredFix selects the fix offered for a chain when both can be built: "param_struct" (default) or "method_object"
+	PreferredFix string `json:"preferred_fix" enum:"param_struct,method_object"`
+	// MaxParams defines the maximum number of parameters of a single function; 0 disables the check
+	MaxParams int `json:"max_params" min:"0"`
+	// MaxParamsExported overrides MaxParams for exported functions
+	MaxParamsExported int `json:"max_params_exported" min:"0"`
+	// MaxParamsMethods overrides MaxParams for methods
+	MaxParamsMethods int `json:"max_params_methods" min:"0"`
+	// MaxParamsConstructors overrides MaxParams for constructors (New...)
+	MaxParamsConstructors int `json:"max_params_constructors" min:"0"`
+	// ExcludeFunctions lists regular expressions matched against fully-qualified function names
+	// such as example.com/pkg.Func or example.com/pkg.Type.Method
+	ExcludeFunctions []string `json:"exclude_functions"`
+	// ExcludePackages lists package path globs; a pattern ending in /... also matches subpackages
+	ExcludePackages []string `json:"exclude_packages"`
+	// ExcludeFiles lists file globs matched against the file name, or against the end of the path if they contain a slash
+	ExcludeFiles []string `json:"exclude_files"`
+	// OnlyExported reports only exported functions and chains rooted at them
+	OnlyExported bool `json:"only_exported"`
+	// SkipTests excludes test files
+	SkipTests bool `json:"skip_tests"`
+	// Generated selects how generated files are handled: "ignore" (default) or "traverse"
+	Generated string `json:"generated" enum:"ignore,traverse"`
+	// Overrides tunes the chain thresholds of packages matching the patterns; later entries win
+	Overrides []Override `json:"overrides"`
+}
+
+// Override holds chain thresholds for a set of packages. Omitted values keep the global settings
+type Override struct {
+	// Packages lists package path globs; a pattern ending in /... also matches subpackages
+	Packages []string `json:"packages"`
+	// MinRequiredParams overrides Config.MinRequiredParams
+	MinRequiredParams int `json:"min_required_params" min:"1"`
+	// MaxRecursionDepth overrides Config.MaxRecursionDepth
+	MaxRecursionDepth int `json:"max_recursion_depth" min:"1"`
+	// MinGroupSize overrides Config.MinGroupSize
+	MinGroupSize int `json:"min_group_size" min:"2"`
+	// MinChainLength overrides Config.MinChainLength
+	MinChainLength int `json:"min_chain_length" min:"2"`
 }
 
 // DefaultConfig returns the default configuration
 func DefaultConfig() Config {
 	return Config{
-		MinRequiredParams: 2,
-		MaxRecursionDepth: 10,
+		MinRequiredParams:    2,
+		MaxRecursionDepth:    10,
+		MinGroupSize:         3,
+		MinChainLength:       2,
+		MinClumpSize:         3,
+		MinClumpOccurrences:  3,
+		MinOptionalParams:    2,
+		MinFlagParams:        2,
+		PointerSizeThreshold: 80,
+		PreferredFix:         "param_struct",
+		Generated:            "ignore",
 	}
 }
 
 type PluginUsestructModule struct {
 	config Config
+	// configs, if set, configures each package by the configuration files of its directory
+	configs *dirConfigs
 }
 
+// New creates the plugin from golangci-lint settings. Settings are validated strictly:
+// unknown keys, values of the wrong type and out-of-range values are reported as errors
+// naming the offending key, so a misconfigured linter fails instead of running with defaults
 func New(settings any) (register.LinterPlugin, error) {
-	config := DefaultConfig()
+	config, err := parseSettings(settings)
+	if err != nil {
+		return nil, fmt.Errorf("usestruct: %w", err)
+	}
+	return PluginUsestructModule{config: config}, nil
+}
 
+// parseSettings validates raw settings and applies them on top of the default configuration
+func parseSettings(settings any) (Config, error) {
+	config := DefaultConfig()
 	if settings == nil {
-		return PluginUsestructModule{config: config}, nil
+		return config, nil
 	}
 
-	// Try to parse settings as JSON
 	settingsMap, ok := settings.(map[string]any)
 	if !ok {
-		return PluginUsestructModule{config: config}, nil
+		return Config{}, fmt.Errorf("settings: expected a map, got %T", settings)
+	}
+	if err := validateSettings(settingsMap, reflect.TypeOf(config), "settings"); err != nil {
+		return Config{}, err
 	}
 
-	// Convert map to JSON and then to our config struct
+	// Convert map to JSON and then decode it over the defaults, so omitted keys keep them
 	jsonBytes, err := json.Marshal(settingsMap)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal settings: %w", err)
+		return Config{}, fmt.Errorf("failed to marshal settings: %w", err)
+	}
+	if err := json.Unmarshal(jsonBytes, &config); err != nil {
+		return Config{}, fmt.Errorf("failed to unmarshal settings: %w", err)
 	}
 
-	var parsedConfig Config
-	if err := json.Unmarshal(jsonBytes, &parsedConfig); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal settings: %w", err)
+	if _, err := config.options(); err != nil {
+		return Config{}, err
 	}
+	return config, nil
+}
 
-	// Use parsed values if they are valid (> 0), otherwise keep defaults
-	if parsedConfig.MinRequiredParams > 0 {
-		config.MinRequiredParams = parsedConfig.MinRequiredParams
+func (f PluginUsestructModule) BuildAnalyzers() ([]*analysis.Analyzer, error) {
+	opts, err := f.config.options()
+	if err != nil {
+		return nil, err
 	}
-	if parsedConfig.MaxRecursionDepth > 0 {
-		config.MaxRecursionDepth = parsedConfig.MaxRecursionDepth
+	longParams := f.config.longParamsOptions(opts.Filter)
+	if f.configs != nil {
+		opts.ForDir = f.configs.options
+		longParams.ForDir = f.configs.longParamsOptions
 	}
+	return []*analysis.Analyzer{
+		analyzer.AnalyzerWithOptions(opts),
+		analyzer.LongParamsAnalyzer(longParams),
+	}, nil
+}
 
-	return PluginUsestructModule{config: config}, nil
+// filter compiles the exclusion settings
+func (c Config) filter() (analyzer.Filter, error) {
+	filter := analyzer.Filter{
+		ExcludePackages: c.ExcludePackages,
+		ExcludeFiles:    c.ExcludeFiles,
+		OnlyExported:    c.OnlyExported,
+		SkipTests:       c.SkipTests,
+	}
+	switch c.Generated {
+	case "", "ignore":
+		filter.Generated = analyzer.GeneratedIgnore
+	case "traverse":
+		filter.Generated = analyzer.GeneratedTraverse
+	default:
+		return analyzer.Filter{}, fmt.Errorf("invalid generated mode %q: want ignore or traverse", c.Generated)
+	}
+	for _, expr := range c.ExcludeFunctions {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return analyzer.Filter{}, fmt.Errorf("invalid exclude_functions pattern %q: %w", expr, err)
+		}
+		filter.ExcludeFunctions = append(filter.ExcludeFunctions, re)
+	}
+	for _, pattern := range c.ExcludePackages {
+		if !analyzer.ValidPattern(pattern) {
+			return analyzer.Filter{}, fmt.Errorf("invalid exclude_packages pattern %q", pattern)
+		}
+	}
+	for _, pattern := range c.ExcludeFiles {
+		if !analyzer.ValidPattern(pattern) {
+			return analyzer.Filter{}, fmt.Errorf("invalid exclude_files pattern %q", pattern)
+		}
+	}
+	return filter, nil
 }
 
-func (f PluginUsestructModule) BuildAnalyzers() ([]*analysis.Analyzer, error) {
-	return []*analysis.Analyzer{
-		analyzer.AnalyzerWithConfig(f.config.MinRequiredParams, f.config.MaxRecursionDepth),
+// options converts the plugin configuration into analyzer options, validating patterns
+func (c Config) options() (analyzer.Options, error) {
+	filter, err := c.filter()
+	if err != nil {
+		return analyzer.Options{}, err
+	}
+	overrides, err := c.overrides()
+	if err != nil {
+		return analyzer.Options{}, err
+	}
+	var preferredFix analyzer.FixKind
+	switch c.PreferredFix {
+	case "", "param_struct":
+		preferredFix = analyzer.FixParamStruct
+	case "method_object":
+		preferredFix = analyzer.FixMethodObject
+	default:
+		return analyzer.Options{}, fmt.Errorf("invalid preferred_fix %q: want param_struct or method_object", c.PreferredFix)
+	}
+	return analyzer.Options{
+		MinRequiredParams:   c.MinRequiredParams,
+		MaxRecursionDepth:   c.MaxRecursionDepth,
+		MinGroupSize:        c.MinGroupSize,
+		MinChainLength:      c.MinChainLength,
+		MinClumpSize:        c.MinClumpSize,
+		MinClumpOccurrences: c.MinClumpOccurrences,
+		MinOptionalParams:   c.MinOptionalParams,
+		MinFlagParams:       c.MinFlagParams,
+		PointerThreshold:    c.PointerSizeThreshold,
+		CompatWrappers:      c.CompatWrappers,
+		PreferredFix:        preferredFix,
+		Filter:              filter,
+		Overrides:           overrides,
 	}, nil
 }
 
+// overrides converts the per-package overrides, validating their patterns
+func (c Config) overrides() ([]analyzer.Override, error) {
+	overrides := make([]analyzer.Override, 0, len(c.Overrides))
+	for i, o := range c.Overrides {
+		if len(o.Packages) == 0 {
+			return nil, fmt.Errorf("overrides[%d]: packages must not be empty", i)
+		}
+		for _, pattern := range o.Packages {
+			if !analyzer.ValidPattern(pattern) {
+				return nil, fmt.Errorf("invalid overrides[%d].packages pattern %q", i, pattern)
+			}
+		}
+		overrides = append(overrides, analyzer.Override{
+			Packages:          o.Packages,
+			MinRequiredParams: o.MinRequiredParams,
+			MaxRecursionDepth: o.MaxRecursionDepth,
+			MinGroupSize:      o.MinGroupSize,
+			MinChainLength:    o.MinChainLength,
+		})
+	}
+	return overrides, nil
+}
+
+// longParamsOptions converts the plugin configuration into long parameter list limits
+func (c Config) longParamsOptions(filter analyzer.Filter) analyzer.LongParamsOptions {
+	return analyzer.LongParamsOptions{
+		Max:             c.MaxParams,
+		MaxExported:     c.MaxParamsExported,
+		MaxMethods:      c.MaxParamsMethods,
+		MaxConstructors: c.MaxParamsConstructors,
+		Filter:          filter,
+	}
+}
+
 func (f PluginUsestructModule) GetLoadMode() string {
-	return register.LoadModeSyntax
+	return register.LoadModeTypesInfo
 }
@@ -0,0 +1,207 @@
+package usestruct
+
+import (
+	"encoding/json"
+	"maps"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestNewSettings(t *testing.T) {
+	config, err := parseSettings(map[string]any{
+		"min_required_params": 3,
+		"max_params":          float64(7),
+		"exclude_packages":    []any{"example.com/mocks/..."},
+		"compat_wrappers":     true,
+		"preferred_fix":       "method_object",
+		"generated":           "traverse",
+		"overrides": []any{
+			map[string]any{"packages": []any{"example.com/handlers/..."}, "min_group_size": 4, "min_chain_length": 3},
+		},
+	})
+	if err != nil {
+		t.Fatalf("parseSettings() error = %v", err)
+	}
+
+	want := DefaultConfig()
+	want.MinRequiredParams = 3
+	want.MaxParams = 7
+	want.ExcludePackages = []string{"example.com/mocks/..."}
+	want.CompatWrappers = true
+	want.PreferredFix = "method_object"
+	want.Generated = "traverse"
+	want.Overrides = []Override{{Packages: []string{"example.com/handlers/..."}, MinGroupSize: 4, MinChainLength: 3}}
+	if !reflect.DeepEqual(config, want) {
+		t.Errorf("parseSettings() = %+v, want %+v", config, want)
+	}
+}
+
+func TestNewSettingsErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings any
+		expected string
+	}{
+		{
+			name:     "not a map",
+			settings: []any{"min_required_params"},
+			expected: "settings: expected a map, got []interface {}",
+		},
+		{
+			name:     "typo",
+			settings: map[string]any{"min_requried_params": 3},
+			expected: "settings.min_requried_params: unknown key, did you mean min_required_params?",
+		},
+		{
+			name:     "unknown key",
+			settings: map[string]any{"verbose": true},
+			expected: "settings.verbose: unknown key",
+		},
+		{
+			name:     "wrong type",
+			settings: map[string]any{"max_params": "5"},
+			expected: "settings.max_params: expected integer, got string (5)",
+		},
+		{
+			name:     "fractional number",
+			settings: map[string]any{"max_params": 5.5},
+			expected: "settings.max_params: expected integer, got float64 (5.5)",
+		},
+		{
+			name:     "out of range",
+			settings: map[string]any{"min_clump_size": 1},
+			expected: "settings.min_clump_size: must be at least 2, got 1",
+		},
+		{
+			name:     "negative",
+			settings: map[string]any{"max_params_methods": -1},
+			expected: "settings.max_params_methods: must be at least 0, got -1",
+		},
+		{
+			name:     "list element",
+			settings: map[string]any{"exclude_files": []any{"*.pb.go", 1}},
+			expected: "settings.exclude_files[1]: expected string, got int (1)",
+		},
+		{
+			name:     "enum",
+			settings: map[string]any{"generated": "skip"},
+			expected: `settings.generated: must be one of ignore, traverse, got "skip"`,
+		},
+		{
+			name:     "override key",
+			settings: map[string]any{"overrides": []any{map[string]any{"packages": []any{"example.com/api"}, "min_group_size": 1}}},
+			expected: "settings.overrides[0].min_group_size: must be at least 2, got 1",
+		},
+		{
+			name:     "override without packages",
+			settings: map[string]any{"overrides": []any{map[string]any{"min_chain_length": 3}}},
+			expected: "overrides[0]: packages must not be empty",
+		},
+		{
+			name:     "invalid pattern",
+			settings: map[string]any{"exclude_files": []any{"mocks/[.go"}},
+			expected: `invalid exclude_files pattern "mocks/[.go"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.settings)
+			if err == nil {
+				t.Fatal("New() error = nil")
+			}
+			if want := "usestruct: " + tt.expected; err.Error() != want {
+				t.Errorf("New() error = %q, want %q", err, want)
+			}
+		})
+	}
+}
+
+// schemaNode is the part of a JSON Schema checked against the Config fields
+type schemaNode struct {
+	Type                 string                 `json:"type"`
+	AdditionalProperties *bool                  `json:"additionalProperties"`
+	Properties           map[string]*schemaNode `json:"properties"`
+	Items                *schemaNode            `json:"items"`
+	Minimum              *int64                 `json:"minimum"`
+	Enum                 []string               `json:"enum"`
+	Default              json.RawMessage        `json:"default"`
+}
+
+// TestSchemaMatchesConfig keeps usestruct.schema.json in sync with the Config fields and their tags
+func TestSchemaMatchesConfig(t *testing.T) {
+	data, err := os.ReadFile("usestruct.schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var schema schemaNode
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatal(err)
+	}
+
+	defaults, err := json.Marshal(DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var defaultValues map[string]json.RawMessage
+	if err := json.Unmarshal(defaults, &defaultValues); err != nil {
+		t.Fatal(err)
+	}
+
+	checkSchema(t, &schema, reflect.TypeOf(Config{}), "settings", defaultValues)
+}
+
+// checkSchema compares an object schema with the fields of a struct type
+func checkSchema(t *testing.T, schema *schemaNode, typ reflect.Type, path string, defaults map[string]json.RawMessage) {
+	t.Helper()
+	if schema.Type != "object" || schema.AdditionalProperties == nil || *schema.AdditionalProperties {
+		t.Errorf("%s: schema must be an object without additional properties", path)
+	}
+
+	properties := maps.Clone(schema.Properties)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		key := jsonKey(field)
+		prop, ok := properties[key]
+		if !ok {
+			t.Errorf("%s: schema has no property %s", path, key)
+			continue
+		}
+		delete(properties, key)
+
+		wantType := map[reflect.Kind]string{
+			reflect.Int:    "integer",
+			reflect.Bool:   "boolean",
+			reflect.String: "string",
+			reflect.Slice:  "array",
+		}[field.Type.Kind()]
+		if prop.Type != wantType {
+			t.Errorf("%s.%s: schema type %q, want %q", path, key, prop.Type, wantType)
+		}
+		if limit, ok := field.Tag.Lookup("min"); ok {
+			low, _ := strconv.ParseInt(limit, 10, 64)
+			if prop.Minimum == nil || *prop.Minimum != low {
+				t.Errorf("%s.%s: schema minimum %v, want %d", path, key, prop.Minimum, low)
+			}
+		}
+		if enum, ok := field.Tag.Lookup("enum"); ok && strings.Join(prop.Enum, ",") != enum {
+			t.Errorf("%s.%s: schema enum %v, want %s", path, key, prop.Enum, enum)
+		}
+		if prop.Default != nil && string(prop.Default) != string(defaults[key]) {
+			t.Errorf("%s.%s: schema default %s, want %s", path, key, prop.Default, defaults[key])
+		}
+		if field.Type.Kind() == reflect.Slice && field.Type.Elem().Kind() == reflect.Struct {
+			if prop.Items == nil {
+				t.Errorf("%s.%s: schema has no items", path, key)
+				continue
+			}
+			checkSchema(t, prop.Items, field.Type.Elem(), path+"."+key+"[]", nil)
+		}
+	}
+	for key := range properties {
+		t.Errorf("%s: schema property %s has no field", path, key)
+	}
+}